@@ -0,0 +1,385 @@
+package generic
+
+import (
+	"sync"
+	"time"
+)
+
+// EvictionPolicy chooses which entry a full Cache removes to make room
+type EvictionPolicy int
+
+const (
+	// LRU evicts the least recently used entry
+	LRU EvictionPolicy = iota
+	// LFU evicts the least frequently used entry, breaking ties by
+	// evicting the least recently used of them
+	LFU
+)
+
+// EvictionReason says why an entry left a Cache
+type EvictionReason int
+
+const (
+	// EvictedCapacity means the entry was removed to make room
+	EvictedCapacity EvictionReason = iota
+	// EvictedExpired means the entry's TTL passed
+	EvictedExpired
+	// EvictedDeleted means the entry was removed by Delete or Clear
+	EvictedDeleted
+)
+
+// CacheOptions configures NewCache. The zero value is an unbounded LRU
+// cache without expiry.
+type CacheOptions[K comparable, V any] struct {
+	// Capacity is the maximum number of entries. Zero means unbounded.
+	Capacity int
+	// Policy picks the entry to evict when the cache is full
+	Policy EvictionPolicy
+	// TTL is the default time to live for entries. Zero means entries
+	// do not expire unless set with SetWithTTL.
+	TTL time.Duration
+	// Now is the clock used for expiry. It defaults to time.Now and can
+	// be replaced in tests.
+	Now func() time.Time
+	// OnEvict, if set, is called for every entry that leaves the cache,
+	// except entries that are overwritten by Set. It is called without
+	// the cache lock held so it may use the cache.
+	OnEvict func(k K, v V, reason EvictionReason)
+}
+
+// CacheStats counts cache activity since the cache was created
+type CacheStats struct {
+	Hits        int
+	Misses      int
+	Evictions   int // removed for capacity
+	Expirations int // removed because their TTL passed
+}
+
+// Cache is a key value cache with a bounded size, LRU or LFU eviction, and
+// optional expiry. It is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	opts    CacheOptions[K, V]
+	entries map[K]*cacheEntry[K, V]
+	order   cacheOrder[K]
+	expiry  *PriorityQueue[K, time.Time] // entries with a TTL, soonest first
+	stats   CacheStats
+}
+
+type cacheEntry[K comparable, V any] struct {
+	value   V
+	expires *PriorityItem[K, time.Time] // nil for no expiry
+	freq    int
+}
+
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// cacheOrder tracks which key an eviction policy would remove next
+type cacheOrder[K comparable] interface {
+	add(k K)
+	touch(k K, freq int)
+	remove(k K, freq int)
+	victim() K
+}
+
+// NewCache returns an empty Cache configured by opts
+func NewCache[K comparable, V any](opts CacheOptions[K, V]) *Cache[K, V] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	c := &Cache[K, V]{
+		opts:    opts,
+		entries: make(map[K]*cacheEntry[K, V]),
+		expiry:  NewPriorityQueueFunc[K](time.Time.Compare),
+	}
+	switch opts.Policy {
+	case LFU:
+		c.order = &lfuOrder[K]{buckets: make(map[int]*OrderedMap[K, struct{}])}
+	default:
+		c.order = &lruOrder[K]{}
+	}
+	return c
+}
+
+// Get returns the value for k and whether it was present and unexpired.
+// It counts as a use of k for eviction and in the statistics.
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	now := c.opts.Now()
+	c.mu.Lock()
+	e, ok := c.entries[k]
+	var evicted []eviction[K, V]
+	if ok && e.expiredAt(now) {
+		evicted = append(evicted, c.removeLocked(k, e, EvictedExpired))
+		ok = false
+	}
+	var v V
+	if ok {
+		c.stats.Hits++
+		e.freq++
+		c.order.touch(k, e.freq-1)
+		v = e.value
+	} else {
+		c.stats.Misses++
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+	return v, ok
+}
+
+// Peek returns the value for k and whether it was present and unexpired
+// without counting as a use of k
+func (c *Cache[K, V]) Peek(k K) (V, bool) {
+	now := c.opts.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[k]
+	if !ok || e.expiredAt(now) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores v under k using the default TTL
+func (c *Cache[K, V]) Set(k K, v V) {
+	c.SetWithTTL(k, v, c.opts.TTL)
+}
+
+// SetWithTTL stores v under k, expiring after ttl. A ttl of zero means
+// the entry does not expire. A negative ttl means v has already expired,
+// so it is not stored and any existing entry for k is removed as expired.
+//
+// Expired entries are removed before anything is evicted for capacity,
+// so a full cache only evicts a live entry when none have expired.
+func (c *Cache[K, V]) SetWithTTL(k K, v V, ttl time.Duration) {
+	now := c.opts.Now()
+	c.mu.Lock()
+	evicted := c.removeExpiredLocked(now)
+	e, ok := c.entries[k]
+	switch {
+	case ttl < 0:
+		if ok {
+			evicted = append(evicted, c.removeLocked(k, e, EvictedExpired))
+		}
+	case ok:
+		e.value = v
+		c.setExpiryLocked(k, e, now, ttl)
+		e.freq++
+		c.order.touch(k, e.freq-1)
+	default:
+		if c.opts.Capacity > 0 && len(c.entries) >= c.opts.Capacity {
+			victim := c.order.victim()
+			evicted = append(evicted, c.removeLocked(victim, c.entries[victim], EvictedCapacity))
+		}
+		e = &cacheEntry[K, V]{value: v, freq: 1}
+		c.setExpiryLocked(k, e, now, ttl)
+		c.entries[k] = e
+		c.order.add(k)
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// Delete removes k, returning true if it was present
+func (c *Cache[K, V]) Delete(k K) bool {
+	c.mu.Lock()
+	e, ok := c.entries[k]
+	var evicted []eviction[K, V]
+	if ok {
+		evicted = append(evicted, c.removeLocked(k, e, EvictedDeleted))
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+	return ok
+}
+
+// Clear removes all entries. Statistics are kept.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	evicted := make([]eviction[K, V], 0, len(c.entries))
+	for k, e := range c.entries {
+		evicted = append(evicted, c.removeLocked(k, e, EvictedDeleted))
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// RemoveExpired removes all expired entries. Expired entries are also
+// removed by every Set and as they are found by Get.
+func (c *Cache[K, V]) RemoveExpired() {
+	now := c.opts.Now()
+	c.mu.Lock()
+	evicted := c.removeExpiredLocked(now)
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// Len returns the number of entries, including any that have expired
+// but not yet been removed
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Stats returns the hit, miss, and eviction counts
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Snapshot returns the unexpired entries as a plain map for use with the
+// other map functions. It does not count as a use of any key.
+func (c *Cache[K, V]) Snapshot() map[K]V {
+	now := c.opts.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := make(map[K]V, len(c.entries))
+	for k, e := range c.entries {
+		if !e.expiredAt(now) {
+			m[k] = e.value
+		}
+	}
+	return m
+}
+
+// expiredAt returns true if e has expired as of now. Callers read the
+// clock once, before taking the lock, and pass the time in.
+func (e *cacheEntry[K, V]) expiredAt(now time.Time) bool {
+	return e.expires != nil && !now.Before(e.expires.Priority())
+}
+
+// setExpiryLocked must be called with the lock held
+func (c *Cache[K, V]) setExpiryLocked(k K, e *cacheEntry[K, V], now time.Time, ttl time.Duration) {
+	if ttl == 0 {
+		if e.expires != nil {
+			c.expiry.Remove(e.expires)
+			e.expires = nil
+		}
+		return
+	}
+	if e.expires != nil {
+		c.expiry.Update(e.expires, now.Add(ttl))
+	} else {
+		e.expires = c.expiry.Push(k, now.Add(ttl))
+	}
+}
+
+// removeExpiredLocked must be called with the lock held. It takes time
+// proportional to the number of expired entries.
+func (c *Cache[K, V]) removeExpiredLocked(now time.Time) []eviction[K, V] {
+	var evicted []eviction[K, V]
+	for {
+		k, expires, ok := c.expiry.Peek()
+		if !ok || now.Before(expires) {
+			return evicted
+		}
+		evicted = append(evicted, c.removeLocked(k, c.entries[k], EvictedExpired))
+	}
+}
+
+// removeLocked must be called with the lock held
+func (c *Cache[K, V]) removeLocked(k K, e *cacheEntry[K, V], reason EvictionReason) eviction[K, V] {
+	delete(c.entries, k)
+	c.order.remove(k, e.freq)
+	if e.expires != nil {
+		c.expiry.Remove(e.expires)
+	}
+	switch reason {
+	case EvictedCapacity:
+		c.stats.Evictions++
+	case EvictedExpired:
+		c.stats.Expirations++
+	}
+	return eviction[K, V]{key: k, value: e.value, reason: reason}
+}
+
+// notify must be called without the lock held
+func (c *Cache[K, V]) notify(evicted []eviction[K, V]) {
+	if c.opts.OnEvict == nil {
+		return
+	}
+	for _, ev := range evicted {
+		c.opts.OnEvict(ev.key, ev.value, ev.reason)
+	}
+}
+
+// firstKey returns the oldest key in om, which must not be empty
+func firstKey[K comparable, V any](om *OrderedMap[K, V]) K {
+	var first K
+	om.Range(func(k K, _ V) bool {
+		first = k
+		return false
+	})
+	return first
+}
+
+// lruOrder keeps keys from least to most recently used
+type lruOrder[K comparable] struct {
+	keys OrderedMap[K, struct{}]
+}
+
+func (o *lruOrder[K]) add(k K)           { o.keys.Set(k, struct{}{}) }
+func (o *lruOrder[K]) touch(k K, _ int)  { o.keys.MoveToBack(k) }
+func (o *lruOrder[K]) remove(k K, _ int) { o.keys.Delete(k) }
+func (o *lruOrder[K]) victim() K         { return firstKey(&o.keys) }
+
+// lfuOrder keeps a bucket of keys for each use count, each bucket from
+// least to most recently used. add, touch, and remove are O(1). victim is
+// O(1) except after a removal empties the least used bucket, when it
+// scans the remaining buckets once to find the new least count.
+type lfuOrder[K comparable] struct {
+	buckets map[int]*OrderedMap[K, struct{}]
+	minFreq int
+}
+
+func (o *lfuOrder[K]) bucket(freq int) *OrderedMap[K, struct{}] {
+	b, ok := o.buckets[freq]
+	if !ok {
+		b = NewOrderedMap[K, struct{}]()
+		o.buckets[freq] = b
+	}
+	return b
+}
+
+func (o *lfuOrder[K]) add(k K) {
+	o.bucket(1).Set(k, struct{}{})
+	o.minFreq = 1
+}
+
+// touch moves k from the bucket for freq to the bucket for freq+1
+func (o *lfuOrder[K]) touch(k K, freq int) {
+	o.remove(k, freq)
+	o.bucket(freq+1).Set(k, struct{}{})
+	if o.minFreq == 0 || o.minFreq > freq+1 {
+		o.minFreq = freq + 1
+	}
+}
+
+func (o *lfuOrder[K]) remove(k K, freq int) {
+	b := o.buckets[freq]
+	b.Delete(k)
+	if b.Len() == 0 {
+		delete(o.buckets, freq)
+		if o.minFreq == freq {
+			// The next victim() will search upward from here
+			o.minFreq = 0
+		}
+	}
+}
+
+func (o *lfuOrder[K]) victim() K {
+	if o.minFreq == 0 {
+		for freq := range o.buckets {
+			if o.minFreq == 0 || freq < o.minFreq {
+				o.minFreq = freq
+			}
+		}
+	}
+	return firstKey(o.buckets[o.minFreq])
+}
@@ -0,0 +1,276 @@
+package generic_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+// fakeClock is a manually advanced clock for expiry tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestCacheLRU(t *testing.T) {
+	t.Parallel()
+
+	var evicted []string
+	c := generic.NewCache(generic.CacheOptions[string, int]{
+		Capacity: 2,
+		Policy:   generic.LRU,
+		OnEvict: func(k string, _ int, reason generic.EvictionReason) {
+			assert.Equal(t, generic.EvictedCapacity, reason)
+			evicted = append(evicted, k)
+		},
+	})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+	c.Set("c", 3)
+
+	t.Log("Should evict the least recently used key")
+	assert.Equal(t, []string{"b"}, evicted)
+	assert.Equal(t, map[string]int{"a": 1, "c": 3}, c.Snapshot())
+
+	t.Log("Peek should not count as a use")
+	_, ok = c.Peek("a")
+	assert.True(t, ok)
+	c.Set("d", 4)
+	assert.Equal(t, []string{"b", "a"}, evicted)
+
+	t.Log("Overwriting a key should not evict")
+	c.Set("d", 5)
+	assert.Equal(t, 2, c.Len())
+	v, _ := c.Get("d")
+	assert.Equal(t, 5, v)
+}
+
+func TestCacheLFU(t *testing.T) {
+	t.Parallel()
+
+	c := generic.NewCache(generic.CacheOptions[string, int]{Capacity: 3, Policy: generic.LFU})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	for i := 0; i < 3; i++ {
+		c.Get("a")
+	}
+	c.Get("b")
+	c.Get("c")
+	c.Set("d", 4)
+
+	t.Log("Should evict the least frequently used key, oldest first on ties")
+	assert.ElementsMatch(t, []string{"a", "c", "d"}, generic.Keys(c.Snapshot()))
+
+	c.Set("e", 5)
+	t.Log("A new key has the lowest count so it goes next")
+	assert.ElementsMatch(t, []string{"a", "c", "e"}, generic.Keys(c.Snapshot()))
+
+	c.Delete("e")
+	c.Delete("c")
+	c.Set("f", 6)
+	c.Set("g", 7)
+	c.Set("h", 8)
+	assert.ElementsMatch(t, []string{"a", "g", "h"}, generic.Keys(c.Snapshot()))
+	assert.Equal(t, 3, c.Stats().Evictions)
+}
+
+func TestCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	reasons := map[string]generic.EvictionReason{}
+	c := generic.NewCache(generic.CacheOptions[string, int]{
+		TTL: time.Minute,
+		Now: clock.Now,
+		OnEvict: func(k string, _ int, reason generic.EvictionReason) {
+			reasons[k] = reason
+		},
+	})
+	c.Set("default", 1)
+	c.SetWithTTL("short", 2, time.Second)
+	c.SetWithTTL("forever", 3, 0)
+
+	clock.Advance(time.Second)
+	_, ok := c.Get("short")
+	assert.False(t, ok)
+	assert.Equal(t, generic.EvictedExpired, reasons["short"])
+
+	clock.Advance(time.Hour)
+	t.Log("Snapshot should leave out expired entries without removing them")
+	assert.Equal(t, map[string]int{"forever": 3}, c.Snapshot())
+	assert.Equal(t, 2, c.Len())
+
+	c.RemoveExpired()
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, generic.EvictedExpired, reasons["default"])
+	assert.Equal(t, 2, c.Stats().Expirations)
+
+	c.Delete("forever")
+	assert.Equal(t, generic.EvictedDeleted, reasons["forever"])
+
+	t.Log("A negative TTL should remove the key instead of storing it")
+	c.Set("gone", 4)
+	c.SetWithTTL("gone", 5, -time.Second)
+	_, ok = c.Peek("gone")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, generic.EvictedExpired, reasons["gone"])
+}
+
+func TestCacheExpiredBeforeCapacity(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	reasons := map[string]generic.EvictionReason{}
+	c := generic.NewCache(generic.CacheOptions[string, int]{
+		Capacity: 2,
+		Now:      clock.Now,
+		OnEvict: func(k string, _ int, reason generic.EvictionReason) {
+			reasons[k] = reason
+		},
+	})
+	c.Set("live", 1)
+	c.SetWithTTL("short", 2, time.Second)
+	clock.Advance(time.Second)
+	c.Set("new", 3)
+
+	t.Log("Should drop the expired entry rather than evict a live one")
+	assert.Equal(t, map[string]int{"live": 1, "new": 3}, c.Snapshot())
+	assert.Equal(t, map[string]generic.EvictionReason{"short": generic.EvictedExpired}, reasons)
+	assert.Equal(t, 0, c.Stats().Evictions)
+
+	t.Log("Resetting a key without a TTL should stop it expiring")
+	c.SetWithTTL("live", 4, time.Second)
+	c.SetWithTTL("live", 5, 0)
+	clock.Advance(time.Hour)
+	c.RemoveExpired()
+	v, ok := c.Get("live")
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+}
+
+func TestCacheRemovesExpiredOnSet(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := generic.NewCache(generic.CacheOptions[int, int]{TTL: time.Minute, Now: clock.Now})
+	for i := 0; i < 1000; i++ {
+		c.Set(i, i)
+	}
+	clock.Advance(time.Minute)
+	c.Set(-1, -1)
+
+	t.Log("An unbounded cache should not keep expired entries that are never read")
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, 1000, c.Stats().Expirations)
+}
+
+func TestCacheStats(t *testing.T) {
+	t.Parallel()
+
+	c := generic.NewCache(generic.CacheOptions[int, string]{})
+	c.Set(1, "one")
+	c.Get(1)
+	c.Get(1)
+	c.Get(2)
+	c.Peek(2)
+
+	assert.Equal(t, generic.CacheStats{Hits: 2, Misses: 1}, c.Stats())
+
+	t.Log("Clear should keep statistics")
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 2, c.Stats().Hits)
+}
+
+func TestCacheSnapshot(t *testing.T) {
+	t.Parallel()
+
+	c := generic.NewCache(generic.CacheOptions[string, int]{Capacity: 10})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	before := generic.CopyMap(c.Snapshot())
+
+	c.Set("b", 20)
+	c.Set("c", 3)
+	diff := generic.DiffMaps(before, c.Snapshot())
+
+	assert.Equal(t, map[string]int{"c": 3}, diff.Added)
+	assert.Equal(t, map[string]generic.ValueChange[int]{"b": {Old: 2, New: 20}}, diff.Changed)
+	assert.Equal(t, generic.NewSet("a"), diff.Unchanged)
+}
+
+func TestCacheReadsClockOutsideLock(t *testing.T) {
+	t.Parallel()
+
+	var c *generic.Cache[int, int]
+	var calls int
+	c = generic.NewCache(generic.CacheOptions[int, int]{
+		TTL: time.Minute,
+		Now: func() time.Time {
+			// The clock runs without the lock so this must not deadlock
+			_ = c.Len()
+			calls++
+			return time.Unix(0, 0)
+		},
+	})
+	for i := 0; i < 100; i++ {
+		c.Set(i, i)
+	}
+	c.Get(0)
+	c.Peek(0)
+	c.RemoveExpired()
+
+	calls = 0
+	t.Log("Snapshot should read the clock once, not once per entry")
+	assert.Len(t, c.Snapshot(), 100)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var c *generic.Cache[int, int]
+	c = generic.NewCache(generic.CacheOptions[int, int]{
+		Capacity: 16,
+		Policy:   generic.LFU,
+		OnEvict: func(int, int, generic.EvictionReason) {
+			// Callbacks run without the lock so this must not deadlock
+			_ = c.Len()
+		},
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				c.Set(i%32, g)
+				c.Get((i + g) % 32)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, c.Len(), 16)
+	stats := c.Stats()
+	assert.Equal(t, 800, stats.Hits+stats.Misses)
+}
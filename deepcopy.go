@@ -0,0 +1,96 @@
+package generic
+
+import "reflect"
+
+// Cloner is implemented by types that know how to make a deep copy of
+// themselves. DeepCopySlice and DeepCopyMap use Clone automatically for
+// elements that implement it.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// cloneOrCopy returns v.Clone() if v is a Cloner and v otherwise. A nil
+// pointer, map, or slice is returned as is without calling Clone.
+func cloneOrCopy[T any](v T) T {
+	if c, ok := any(v).(Cloner[T]); ok && !isNil(v) {
+		return c.Clone()
+	}
+	return v
+}
+
+func isNil[T any](v T) bool {
+	rv := reflect.ValueOf(any(v))
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func pickCloner[T any](clone func(T) T) func(T) T {
+	if clone == nil {
+		return cloneOrCopy[T]
+	}
+	return clone
+}
+
+// DeepCopySlice returns a new slice with each element copied by clone.
+// If clone is nil, elements that implement Cloner are cloned with Clone
+// and other elements, including nil pointers, are copied by assignment.
+func DeepCopySlice[T any](orig []T, clone func(T) T) []T {
+	clone = pickCloner(clone)
+	c := make([]T, len(orig))
+	for i, e := range orig {
+		c[i] = clone(e)
+	}
+	return c
+}
+
+// DeepCopyMap returns a new map with each value copied by clone. Keys
+// are copied by assignment. If clone is nil, values that implement Cloner
+// are cloned with Clone and other values, including nil pointers, are
+// copied by assignment.
+// A nil map copies to nil.
+func DeepCopyMap[K comparable, V any](m map[K]V, clone func(V) V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	clone = pickCloner(clone)
+	newM := make(map[K]V, len(m))
+	for k, v := range m {
+		newM[k] = clone(v)
+	}
+	return newM
+}
+
+// copySliceKeepNil is CopySlice except that nil stays nil
+func copySliceKeepNil[T any](orig []T) []T {
+	if orig == nil {
+		return nil
+	}
+	return CopySlice(orig)
+}
+
+// CopyMapOfSlices copies a map and each of the slices in it so that
+// nothing is shared with the original. Nil slices stay nil.
+func CopyMapOfSlices[K comparable, V any](m map[K][]V) map[K][]V {
+	return DeepCopyMap(m, copySliceKeepNil[V])
+}
+
+// CopyMapOfMaps copies a map and each of the maps in it so that
+// nothing is shared with the original. Nil maps stay nil.
+func CopyMapOfMaps[K1 comparable, K2 comparable, V any](m map[K1]map[K2]V) map[K1]map[K2]V {
+	return DeepCopyMap(m, CopyMap[K2, V])
+}
+
+// CopySliceOfSlices copies a slice and each of the slices in it so
+// that nothing is shared with the original. Nil slices stay nil.
+func CopySliceOfSlices[T any](orig [][]T) [][]T {
+	return DeepCopySlice(orig, copySliceKeepNil[T])
+}
+
+// CopySliceOfMaps copies a slice and each of the maps in it so that
+// nothing is shared with the original. Nil maps stay nil.
+func CopySliceOfMaps[K comparable, V any](orig []map[K]V) []map[K]V {
+	return DeepCopySlice(orig, CopyMap[K, V])
+}
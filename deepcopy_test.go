@@ -0,0 +1,155 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+type cloneable struct {
+	Tags []string
+}
+
+func (c *cloneable) Clone() *cloneable {
+	return &cloneable{Tags: generic.CopySlice(c.Tags)}
+}
+
+func TestDeepCopySlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uses cloner function", func(t *testing.T) {
+		t.Parallel()
+
+		original := [][]int{{1, 2}, {3}}
+		copied := generic.DeepCopySlice(original, generic.CopySlice[int])
+
+		t.Log("Modifying original elements should not affect copy")
+		assert.Equal(t, original, copied)
+		original[0][0] = 99
+		assert.Equal(t, 1, copied[0][0])
+	})
+
+	t.Run("detects Cloner", func(t *testing.T) {
+		t.Parallel()
+
+		original := []*cloneable{{Tags: []string{"a"}}}
+		copied := generic.DeepCopySlice(original, nil)
+
+		t.Log("Should call Clone on elements that implement Cloner")
+		assert.Equal(t, original, copied)
+		original[0].Tags[0] = "b"
+		assert.Equal(t, "a", copied[0].Tags[0])
+	})
+
+	t.Run("nil Cloner elements", func(t *testing.T) {
+		t.Parallel()
+
+		original := []*cloneable{nil, {Tags: []string{"a"}}}
+		copied := generic.DeepCopySlice(original, nil)
+
+		t.Log("Should copy nil pointers as nil without calling Clone")
+		assert.Nil(t, copied[0])
+		assert.Equal(t, original[1], copied[1])
+		assert.NotSame(t, original[1], copied[1])
+	})
+
+	t.Run("plain elements", func(t *testing.T) {
+		t.Parallel()
+
+		original := []int{1, 2, 3}
+		copied := generic.DeepCopySlice(original, nil)
+
+		t.Log("Should copy elements that are not Cloners by assignment")
+		assert.Equal(t, original, copied)
+		original[0] = 99
+		assert.Equal(t, 1, copied[0])
+	})
+}
+
+func TestDeepCopyMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("detects Cloner", func(t *testing.T) {
+		t.Parallel()
+
+		original := map[string]*cloneable{"x": {Tags: []string{"a"}}}
+		copied := generic.DeepCopyMap(original, nil)
+
+		t.Log("Should call Clone on values that implement Cloner")
+		assert.Equal(t, original, copied)
+		original["x"].Tags[0] = "b"
+		assert.Equal(t, "a", copied["x"].Tags[0])
+	})
+
+	t.Run("nil Cloner values", func(t *testing.T) {
+		t.Parallel()
+
+		original := map[string]*cloneable{"x": nil}
+		copied := generic.DeepCopyMap(original, nil)
+
+		t.Log("Should copy nil pointers as nil without calling Clone")
+		assert.Equal(t, original, copied)
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		t.Parallel()
+
+		var original map[string]int
+		assert.Nil(t, generic.DeepCopyMap(original, nil))
+	})
+}
+
+func TestCopyNested(t *testing.T) {
+	t.Parallel()
+
+	t.Run("map of slices", func(t *testing.T) {
+		t.Parallel()
+
+		original := map[string][]int{"a": {1, 2}, "nil": nil}
+		copied := generic.CopyMapOfSlices(original)
+
+		t.Log("Inner slices should not be shared and nil should stay nil")
+		assert.Equal(t, original, copied)
+		original["a"][0] = 99
+		assert.Equal(t, 1, copied["a"][0])
+		assert.Nil(t, copied["nil"])
+	})
+
+	t.Run("map of maps", func(t *testing.T) {
+		t.Parallel()
+
+		original := map[string]map[string]int{"a": {"x": 1}}
+		copied := generic.CopyMapOfMaps(original)
+
+		t.Log("Inner maps should not be shared")
+		assert.Equal(t, original, copied)
+		original["a"]["x"] = 99
+		assert.Equal(t, 1, copied["a"]["x"])
+	})
+
+	t.Run("slice of slices", func(t *testing.T) {
+		t.Parallel()
+
+		original := [][]string{{"a"}, nil}
+		copied := generic.CopySliceOfSlices(original)
+
+		t.Log("Inner slices should not be shared")
+		assert.Equal(t, original, copied)
+		original[0][0] = "b"
+		assert.Equal(t, "a", copied[0][0])
+	})
+
+	t.Run("slice of maps", func(t *testing.T) {
+		t.Parallel()
+
+		original := []map[string]int{{"x": 1}}
+		copied := generic.CopySliceOfMaps(original)
+
+		t.Log("Inner maps should not be shared")
+		assert.Equal(t, original, copied)
+		original[0]["x"] = 99
+		assert.Equal(t, 1, copied[0]["x"])
+	})
+}
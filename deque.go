@@ -0,0 +1,235 @@
+package generic
+
+import "fmt"
+
+// Deque is a double-ended queue backed by a circular buffer that grows as
+// needed. Pushing and popping at either end are amortized O(1) and
+// indexing is O(1). The zero value is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int // position of the front element in buf
+	n    int
+}
+
+// NewDeque returns an empty deque with room for capacity elements before
+// it needs to grow
+func NewDeque[T any](capacity int) *Deque[T] {
+	return &Deque[T]{buf: make([]T, max(capacity, 0))}
+}
+
+// PushBack adds v at the back
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.pos(d.n)] = v
+	d.n++
+}
+
+// PushFront adds v at the front
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.pos(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the front element. It returns false if the
+// deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.pos(1)
+	d.n--
+	return v, true
+}
+
+// PopBack removes and returns the back element. It returns false if the
+// deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := d.pos(d.n - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	return v, true
+}
+
+// Front returns the front element. It returns false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element. It returns false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.pos(d.n-1)], true
+}
+
+// At returns the element i positions from the front. Like indexing a
+// slice, it panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	d.checkIndex(i)
+	return d.buf[d.pos(i)]
+}
+
+// Set replaces the element i positions from the front. Like indexing a
+// slice, it panics if i is out of range.
+func (d *Deque[T]) Set(i int, v T) {
+	d.checkIndex(i)
+	d.buf[d.pos(i)] = v
+}
+
+// Len returns the number of elements
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// Clear removes all elements, keeping the allocated buffer
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head = 0
+	d.n = 0
+}
+
+// ToSlice returns a new slice of the elements from front to back
+func (d *Deque[T]) ToSlice() []T {
+	s := make([]T, d.n)
+	d.copyTo(s)
+	return s
+}
+
+// pos returns the buffer position of the element i places from the front
+func (d *Deque[T]) pos(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+func (d *Deque[T]) checkIndex(i int) {
+	if i < 0 || i >= d.n {
+		panic(fmt.Sprintf("generic: Deque index %d out of range with length %d", i, d.n))
+	}
+}
+
+// grow makes room for one more element
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(d.buf), 8))
+	d.copyTo(buf)
+	d.buf = buf
+	d.head = 0
+}
+
+func (d *Deque[T]) copyTo(dst []T) {
+	if d.n == 0 {
+		return
+	}
+	if end := d.head + d.n; end <= len(d.buf) {
+		copy(dst, d.buf[d.head:end])
+		return
+	}
+	k := copy(dst, d.buf[d.head:])
+	copy(dst[k:], d.buf[:d.n-k])
+}
+
+// RingPolicy says what a full Ring does with a new element
+type RingPolicy int
+
+const (
+	// RingOverwrite drops the oldest element to make room
+	RingOverwrite RingPolicy = iota
+	// RingReject refuses the new element
+	RingReject
+)
+
+// Ring is a fixed-capacity FIFO buffer, such as for tailing logs or
+// keeping a window of recent metrics. The zero value is not usable; use
+// NewRing.
+type Ring[T any] struct {
+	d        Deque[T]
+	capacity int
+	policy   RingPolicy
+}
+
+// NewRing returns an empty ring that holds up to capacity elements. It
+// panics if capacity is less than one.
+func NewRing[T any](capacity int, policy RingPolicy) *Ring[T] {
+	if capacity < 1 {
+		panic("generic: Ring capacity must be at least one")
+	}
+	return &Ring[T]{
+		d:        Deque[T]{buf: make([]T, capacity)},
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// Push adds v as the newest element. If the ring is full, RingOverwrite
+// drops the oldest element and RingReject leaves the ring unchanged and
+// returns false.
+func (r *Ring[T]) Push(v T) bool {
+	if r.d.Len() == r.capacity {
+		if r.policy == RingReject {
+			return false
+		}
+		r.d.PopFront()
+	}
+	r.d.PushBack(v)
+	return true
+}
+
+// Pop removes and returns the oldest element. It returns false if the
+// ring is empty.
+func (r *Ring[T]) Pop() (T, bool) {
+	return r.d.PopFront()
+}
+
+// Peek returns the oldest element without removing it. It returns false
+// if the ring is empty.
+func (r *Ring[T]) Peek() (T, bool) {
+	return r.d.Front()
+}
+
+// At returns the element i positions from the oldest. Like indexing a
+// slice, it panics if i is out of range.
+func (r *Ring[T]) At(i int) T {
+	return r.d.At(i)
+}
+
+// Len returns the number of elements
+func (r *Ring[T]) Len() int {
+	return r.d.Len()
+}
+
+// Cap returns the capacity the ring was created with
+func (r *Ring[T]) Cap() int {
+	return r.capacity
+}
+
+// IsFull returns true if the ring holds Cap elements
+func (r *Ring[T]) IsFull() bool {
+	return r.d.Len() == r.capacity
+}
+
+// Clear removes all elements
+func (r *Ring[T]) Clear() {
+	r.d.Clear()
+}
+
+// ToSlice returns a new slice of the elements from oldest to newest
+func (r *Ring[T]) ToSlice() []T {
+	return r.d.ToSlice()
+}
@@ -0,0 +1,138 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestDeque(t *testing.T) {
+	t.Parallel()
+
+	t.Run("both ends", func(t *testing.T) {
+		t.Parallel()
+
+		var d generic.Deque[int]
+		for i := 1; i <= 10; i++ {
+			d.PushBack(i)
+			d.PushFront(-i)
+		}
+		assert.Equal(t, 20, d.Len())
+		assert.Equal(t, []int{-10, -9, -8, -7, -6, -5, -4, -3, -2, -1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, d.ToSlice())
+
+		front, ok := d.Front()
+		assert.True(t, ok)
+		assert.Equal(t, -10, front)
+		back, ok := d.Back()
+		assert.True(t, ok)
+		assert.Equal(t, 10, back)
+
+		v, _ := d.PopFront()
+		assert.Equal(t, -10, v)
+		v, _ = d.PopBack()
+		assert.Equal(t, 10, v)
+		assert.Equal(t, 18, d.Len())
+	})
+
+	t.Run("index access across the wrap", func(t *testing.T) {
+		t.Parallel()
+
+		d := generic.NewDeque[string](4)
+		d.PushBack("c")
+		d.PushBack("d")
+		d.PushFront("b")
+		d.PushFront("a")
+		assert.Equal(t, "a", d.At(0))
+		assert.Equal(t, "d", d.At(3))
+
+		d.Set(1, "B")
+		assert.Equal(t, []string{"a", "B", "c", "d"}, d.ToSlice())
+
+		assert.Panics(t, func() { d.At(4) })
+		assert.Panics(t, func() { d.At(-1) })
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		var d generic.Deque[int]
+		_, ok := d.PopFront()
+		assert.False(t, ok)
+		_, ok = d.PopBack()
+		assert.False(t, ok)
+		_, ok = d.Front()
+		assert.False(t, ok)
+		_, ok = d.Back()
+		assert.False(t, ok)
+
+		t.Log("ToSlice should return an empty slice like CopySlice")
+		assert.Equal(t, generic.CopySlice([]int(nil)), d.ToSlice())
+	})
+
+	t.Run("ToSlice copies", func(t *testing.T) {
+		t.Parallel()
+
+		var d generic.Deque[int]
+		d.PushBack(1)
+		s := d.ToSlice()
+		s[0] = 99
+		assert.Equal(t, 1, d.At(0))
+
+		d.Clear()
+		assert.Equal(t, 0, d.Len())
+		d.PushFront(2)
+		assert.Equal(t, []int{2}, d.ToSlice())
+	})
+}
+
+func TestRing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("overwrite oldest", func(t *testing.T) {
+		t.Parallel()
+
+		r := generic.NewRing[int](3, generic.RingOverwrite)
+		for i := 1; i <= 5; i++ {
+			assert.True(t, r.Push(i))
+		}
+
+		t.Log("Should keep the newest elements")
+		assert.True(t, r.IsFull())
+		assert.Equal(t, 3, r.Cap())
+		assert.Equal(t, []int{3, 4, 5}, r.ToSlice())
+		assert.Equal(t, 4, r.At(1))
+
+		v, ok := r.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, 3, v)
+	})
+
+	t.Run("reject when full", func(t *testing.T) {
+		t.Parallel()
+
+		r := generic.NewRing[string](2, generic.RingReject)
+		assert.True(t, r.Push("a"))
+		assert.True(t, r.Push("b"))
+		assert.False(t, r.Push("c"))
+		assert.Equal(t, []string{"a", "b"}, r.ToSlice())
+
+		v, ok := r.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, "a", v)
+		assert.True(t, r.Push("c"))
+		assert.Equal(t, []string{"b", "c"}, r.ToSlice())
+
+		r.Clear()
+		assert.Equal(t, 0, r.Len())
+		_, ok = r.Pop()
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid capacity", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { generic.NewRing[int](0, generic.RingOverwrite) })
+	})
+}
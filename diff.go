@@ -0,0 +1,95 @@
+package generic
+
+// ValueChange holds the old and new value for a key whose value differs
+// between two maps
+type ValueChange[V any] struct {
+	Old V
+	New V
+}
+
+// MapDiff describes the differences between two maps, a and b, as
+// returned by DiffMaps.
+type MapDiff[K comparable, V any] struct {
+	// Added holds the keys that are only in b, with their values from b
+	Added map[K]V
+	// Removed holds the keys that are only in a, with their values from a
+	Removed map[K]V
+	// Changed holds the keys that are in both maps with different values
+	Changed map[K]ValueChange[V]
+	// Unchanged holds the keys that are in both maps with equal values
+	Unchanged Set[K]
+}
+
+// Patch is a set of changes that can be applied to a map with ApplyMapPatch
+type Patch[K comparable, V any] struct {
+	// Set holds keys to add or overwrite
+	Set map[K]V
+	// Delete holds keys to remove
+	Delete Set[K]
+}
+
+// DiffMaps compares a and b, reporting which keys were added,
+// removed, changed, or left unchanged going from a to b.
+func DiffMaps[K comparable, V comparable](a, b map[K]V) MapDiff[K, V] {
+	return DiffMapsFunc(a, b, func(x, y V) bool {
+		return x == y
+	})
+}
+
+// DiffMapsFunc is DiffMaps for values that are not comparable: equal
+// reports whether two values are the same.
+func DiffMapsFunc[K comparable, V any](a, b map[K]V, equal func(V, V) bool) MapDiff[K, V] {
+	d := MapDiff[K, V]{
+		Added:     make(map[K]V),
+		Removed:   make(map[K]V),
+		Changed:   make(map[K]ValueChange[V]),
+		Unchanged: make(Set[K]),
+	}
+	for k, av := range a {
+		bv, ok := b[k]
+		switch {
+		case !ok:
+			d.Removed[k] = av
+		case equal(av, bv):
+			d.Unchanged.Add(k)
+		default:
+			d.Changed[k] = ValueChange[V]{Old: av, New: bv}
+		}
+	}
+	for k, bv := range b {
+		if _, ok := a[k]; !ok {
+			d.Added[k] = bv
+		}
+	}
+	return d
+}
+
+// Empty returns true if the diff has no added, removed, or changed keys
+func (d MapDiff[K, V]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Patch returns the changes needed to turn a into b
+func (d MapDiff[K, V]) Patch() Patch[K, V] {
+	set := make(map[K]V, len(d.Added)+len(d.Changed))
+	for k, c := range d.Changed {
+		set[k] = c.New
+	}
+	return Patch[K, V]{
+		Set:    Merge(set, d.Added),
+		Delete: ToSet(Keys(d.Removed)),
+	}
+}
+
+// ApplyMapPatch applies p to m: m is modified and returned. If m
+// is nil, a new map is returned.
+func ApplyMapPatch[K comparable, V any](m map[K]V, p Patch[K, V]) map[K]V {
+	m = Merge(m, p.Set)
+	if m == nil {
+		m = make(map[K]V)
+	}
+	for k := range p.Delete {
+		delete(m, k)
+	}
+	return m
+}
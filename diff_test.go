@@ -0,0 +1,101 @@
+package generic_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestDiffMaps(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports all kinds of differences", func(t *testing.T) {
+		t.Parallel()
+
+		desired := map[string]int{"a": 1, "b": 2, "c": 3}
+		actual := map[string]int{"b": 2, "c": 30, "d": 4}
+
+		d := generic.DiffMaps(desired, actual)
+
+		t.Log("Should classify every key")
+		assert.Equal(t, map[string]int{"d": 4}, d.Added)
+		assert.Equal(t, map[string]int{"a": 1}, d.Removed)
+		assert.Equal(t, map[string]generic.ValueChange[int]{"c": {Old: 3, New: 30}}, d.Changed)
+		assert.Equal(t, generic.NewSet("b"), d.Unchanged)
+		assert.False(t, d.Empty())
+	})
+
+	t.Run("identical maps", func(t *testing.T) {
+		t.Parallel()
+
+		m := map[string]int{"a": 1, "b": 2}
+		d := generic.DiffMaps(m, generic.CopyMap(m))
+
+		t.Log("Should report no changes")
+		assert.True(t, d.Empty())
+		assert.Equal(t, generic.NewSet("a", "b"), d.Unchanged)
+	})
+
+	t.Run("nil maps", func(t *testing.T) {
+		t.Parallel()
+
+		d := generic.DiffMaps(nil, map[string]int{"a": 1})
+
+		t.Log("Should treat nil as empty")
+		assert.Equal(t, map[string]int{"a": 1}, d.Added)
+		assert.Empty(t, d.Removed)
+	})
+
+	t.Run("equality function", func(t *testing.T) {
+		t.Parallel()
+
+		a := map[string][]int{"same": {1, 2}, "diff": {1}}
+		b := map[string][]int{"same": {1, 2}, "diff": {2}}
+
+		d := generic.DiffMapsFunc(a, b, slices.Equal[[]int])
+
+		t.Log("Should use the equality function for non-comparable values")
+		assert.Equal(t, generic.NewSet("same"), d.Unchanged)
+		assert.Equal(t, map[string]generic.ValueChange[[]int]{"diff": {Old: []int{1}, New: []int{2}}}, d.Changed)
+	})
+}
+
+func TestApplyMapPatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trips a diff", func(t *testing.T) {
+		t.Parallel()
+
+		a := map[string]int{"a": 1, "b": 2, "c": 3}
+		b := map[string]int{"b": 2, "c": 30, "d": 4}
+
+		p := generic.DiffMaps(a, b).Patch()
+		assert.Equal(t, generic.Patch[string, int]{
+			Set:    map[string]int{"c": 30, "d": 4},
+			Delete: generic.NewSet("a"),
+		}, p)
+		result := generic.ApplyMapPatch(a, p)
+
+		t.Log("Applying the patch to a should produce b")
+		assert.Equal(t, b, result)
+		assert.Equal(t, a, result) // ApplyMapPatch modifies a
+	})
+
+	t.Run("handles nil map", func(t *testing.T) {
+		t.Parallel()
+
+		result := generic.ApplyMapPatch(nil, generic.Patch[string, int]{
+			Set:    map[string]int{"a": 1},
+			Delete: generic.NewSet("b"),
+		})
+
+		t.Log("Should return a new map when input is nil")
+		assert.Equal(t, map[string]int{"a": 1}, result)
+
+		result = generic.ApplyMapPatch(nil, generic.Patch[string, int]{})
+		assert.NotNil(t, result)
+	})
+}
@@ -0,0 +1,261 @@
+package generic
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Heap is a binary heap. The smallest element by its comparator is at the
+// top, so use a reversed comparator for a max heap. The zero value is not
+// usable; use NewHeap or NewHeapFunc.
+type Heap[T any] struct {
+	items []T
+	cmp   func(a, b T) int
+}
+
+// NewHeap returns a min heap holding a copy of items
+func NewHeap[T cmp.Ordered](items ...T) *Heap[T] {
+	return NewHeapFunc(cmp.Compare[T], items...)
+}
+
+// NewHeapFunc returns a heap ordered by cmp holding a copy of items
+func NewHeapFunc[T any](cmp func(a, b T) int, items ...T) *Heap[T] {
+	h := &Heap[T]{items: CopySlice(items), cmp: cmp}
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		heapDown(i, len(h.items), h.less, h.swap)
+	}
+	return h
+}
+
+// Push adds x in O(log n) time
+func (h *Heap[T]) Push(x T) {
+	h.items = append(h.items, x)
+	heapUp(len(h.items)-1, h.less, h.swap)
+}
+
+// Pop removes and returns the top element in O(log n) time. It returns
+// false if the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.items) == 0 {
+		return zero, false
+	}
+	n := len(h.items) - 1
+	h.swap(0, n)
+	heapDown(0, n, h.less, h.swap)
+	top := h.items[n]
+	h.items[n] = zero
+	h.items = h.items[:n]
+	return top, true
+}
+
+// Peek returns the top element without removing it. It returns false if
+// the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// Len returns the number of elements
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// ToSlice returns a copy of the elements in no particular order
+func (h *Heap[T]) ToSlice() []T {
+	return CopySlice(h.items)
+}
+
+func (h *Heap[T]) less(i, j int) bool { return h.cmp(h.items[i], h.items[j]) < 0 }
+func (h *Heap[T]) swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+// PriorityQueue is a heap of values ordered by a separate priority. Push
+// returns a handle that can later change the priority of its value or
+// remove it. The value with the smallest priority by the comparator is
+// served first. The zero value is not usable; use NewPriorityQueue or
+// NewPriorityQueueFunc.
+type PriorityQueue[T any, P any] struct {
+	items []*PriorityItem[T, P]
+	cmp   func(a, b P) int
+}
+
+// PriorityItem is a handle to a value in a PriorityQueue
+type PriorityItem[T any, P any] struct {
+	Value    T
+	priority P
+	index    int // -1 once the item is no longer queued
+	queue    *PriorityQueue[T, P]
+}
+
+// Priority returns the current priority of the item
+func (item *PriorityItem[T, P]) Priority() P {
+	return item.priority
+}
+
+// Queued returns true if the item is still in its queue
+func (item *PriorityItem[T, P]) Queued() bool {
+	return item.index >= 0
+}
+
+// NewPriorityQueue returns an empty queue that serves the lowest priority
+// first
+func NewPriorityQueue[T any, P cmp.Ordered]() *PriorityQueue[T, P] {
+	return NewPriorityQueueFunc[T](cmp.Compare[P])
+}
+
+// NewPriorityQueueFunc returns an empty queue ordered by cmp on priorities
+func NewPriorityQueueFunc[T any, P any](cmp func(a, b P) int) *PriorityQueue[T, P] {
+	return &PriorityQueue[T, P]{cmp: cmp}
+}
+
+// Push adds v with priority p and returns its handle
+func (pq *PriorityQueue[T, P]) Push(v T, p P) *PriorityItem[T, P] {
+	item := &PriorityItem[T, P]{Value: v, priority: p, index: len(pq.items), queue: pq}
+	pq.items = append(pq.items, item)
+	heapUp(item.index, pq.less, pq.swap)
+	return item
+}
+
+// Pop removes and returns the value with the first priority. It returns
+// false if the queue is empty.
+func (pq *PriorityQueue[T, P]) Pop() (T, P, bool) {
+	if len(pq.items) == 0 {
+		var zeroT T
+		var zeroP P
+		return zeroT, zeroP, false
+	}
+	item := pq.items[0]
+	pq.remove(0)
+	return item.Value, item.priority, true
+}
+
+// Peek returns the value with the first priority without removing it. It
+// returns false if the queue is empty.
+func (pq *PriorityQueue[T, P]) Peek() (T, P, bool) {
+	if len(pq.items) == 0 {
+		var zeroT T
+		var zeroP P
+		return zeroT, zeroP, false
+	}
+	return pq.items[0].Value, pq.items[0].priority, true
+}
+
+// Update changes the priority of item, moving it up or down as needed.
+// It returns false if the item is not in this queue.
+func (pq *PriorityQueue[T, P]) Update(item *PriorityItem[T, P], p P) bool {
+	if !pq.owns(item) {
+		return false
+	}
+	item.priority = p
+	if !heapDown(item.index, len(pq.items), pq.less, pq.swap) {
+		heapUp(item.index, pq.less, pq.swap)
+	}
+	return true
+}
+
+// Remove takes item out of the queue. It returns false if the item is
+// not in this queue.
+func (pq *PriorityQueue[T, P]) Remove(item *PriorityItem[T, P]) bool {
+	if !pq.owns(item) {
+		return false
+	}
+	pq.remove(item.index)
+	return true
+}
+
+// Len returns the number of queued values
+func (pq *PriorityQueue[T, P]) Len() int {
+	return len(pq.items)
+}
+
+func (pq *PriorityQueue[T, P]) owns(item *PriorityItem[T, P]) bool {
+	return item != nil && item.queue == pq && item.index >= 0
+}
+
+func (pq *PriorityQueue[T, P]) remove(i int) {
+	n := len(pq.items) - 1
+	item := pq.items[i]
+	if i != n {
+		pq.swap(i, n)
+		if !heapDown(i, n, pq.less, pq.swap) {
+			heapUp(i, pq.less, pq.swap)
+		}
+	}
+	pq.items[n] = nil
+	pq.items = pq.items[:n]
+	item.index = -1
+}
+
+func (pq *PriorityQueue[T, P]) less(i, j int) bool {
+	return pq.cmp(pq.items[i].priority, pq.items[j].priority) < 0
+}
+
+func (pq *PriorityQueue[T, P]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+// heapUp moves element i up until its parent is not greater
+func heapUp(i int, less func(i, j int) bool, swap func(i, j int)) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !less(i, parent) {
+			return
+		}
+		swap(i, parent)
+		i = parent
+	}
+}
+
+// heapDown moves element i down within the first n elements until neither
+// child is smaller. It returns true if the element moved.
+func heapDown(i, n int, less func(i, j int) bool, swap func(i, j int)) bool {
+	start := i
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && less(right, child) {
+			child = right
+		}
+		if !less(child, i) {
+			break
+		}
+		swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// TopK returns the k greatest elements of s by cmp, greatest first. It
+// runs in O(n log k) time and does not modify s. If k is greater than
+// len(s), all elements are returned sorted.
+func TopK[T any](s []T, k int, cmp func(a, b T) int) []T {
+	return BottomK(s, k, func(a, b T) int { return cmp(b, a) })
+}
+
+// BottomK returns the k smallest elements of s by cmp, smallest first.
+// It runs in O(n log k) time and does not modify s. If k is greater than
+// len(s), all elements are returned sorted.
+func BottomK[T any](s []T, k int, cmp func(a, b T) int) []T {
+	if k <= 0 || len(s) == 0 {
+		return nil
+	}
+	k = min(k, len(s))
+	// Keep the k smallest so far in a max heap so the largest of them
+	// is the one to replace
+	h := NewHeapFunc(func(a, b T) int { return cmp(b, a) }, s[:k]...)
+	for _, e := range s[k:] {
+		if cmp(e, h.items[0]) < 0 {
+			h.items[0] = e
+			heapDown(0, len(h.items), h.less, h.swap)
+		}
+	}
+	slices.SortStableFunc(h.items, cmp)
+	return h.items
+}
@@ -0,0 +1,176 @@
+package generic_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func drainHeap[T any](h *generic.Heap[T]) []T {
+	var out []T
+	for {
+		x, ok := h.Pop()
+		if !ok {
+			return out
+		}
+		out = append(out, x)
+	}
+}
+
+func TestHeap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("min heap", func(t *testing.T) {
+		t.Parallel()
+
+		items := []int{5, 2, 8, 1, 9, 3}
+		h := generic.NewHeap(items...)
+		h.Push(0)
+		h.Push(7)
+		assert.Equal(t, 8, h.Len())
+
+		top, ok := h.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, 0, top)
+
+		assert.Equal(t, []int{0, 1, 2, 3, 5, 7, 8, 9}, drainHeap(h))
+		t.Log("Should copy its input")
+		assert.Equal(t, []int{5, 2, 8, 1, 9, 3}, items)
+	})
+
+	t.Run("max heap via comparator", func(t *testing.T) {
+		t.Parallel()
+
+		h := generic.NewHeapFunc(func(a, b string) int { return cmp.Compare(b, a) }, "b", "c", "a")
+		assert.ElementsMatch(t, []string{"a", "b", "c"}, h.ToSlice())
+		assert.Equal(t, []string{"c", "b", "a"}, drainHeap(h))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		h := generic.NewHeap[int]()
+		_, ok := h.Pop()
+		assert.False(t, ok)
+		_, ok = h.Peek()
+		assert.False(t, ok)
+	})
+
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(1))
+		h := generic.NewHeap[int]()
+		var want []int
+		for i := 0; i < 500; i++ {
+			x := r.Intn(100)
+			h.Push(x)
+			want = append(want, x)
+		}
+		slices.Sort(want)
+		assert.Equal(t, want, drainHeap(h))
+	})
+}
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("serves lowest priority first", func(t *testing.T) {
+		t.Parallel()
+
+		pq := generic.NewPriorityQueue[string, int]()
+		pq.Push("low", 10)
+		pq.Push("high", 1)
+		pq.Push("mid", 5)
+
+		v, p, ok := pq.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, "high", v)
+		assert.Equal(t, 1, p)
+
+		var order []string
+		for pq.Len() > 0 {
+			v, _, _ := pq.Pop()
+			order = append(order, v)
+		}
+		assert.Equal(t, []string{"high", "mid", "low"}, order)
+
+		_, _, ok = pq.Pop()
+		assert.False(t, ok)
+	})
+
+	t.Run("update and remove by handle", func(t *testing.T) {
+		t.Parallel()
+
+		pq := generic.NewPriorityQueue[string, int]()
+		a := pq.Push("a", 1)
+		b := pq.Push("b", 2)
+		c := pq.Push("c", 3)
+		d := pq.Push("d", 4)
+
+		t.Log("Should support decrease-key")
+		require.True(t, pq.Update(d, 0))
+		assert.Equal(t, 0, d.Priority())
+		v, _, _ := pq.Peek()
+		assert.Equal(t, "d", v)
+
+		t.Log("Should support increase-key")
+		require.True(t, pq.Update(d, 9))
+		require.True(t, pq.Remove(b))
+		assert.False(t, b.Queued())
+		assert.False(t, pq.Remove(b))
+		assert.False(t, pq.Update(b, 1))
+
+		var order []string
+		for pq.Len() > 0 {
+			v, _, _ := pq.Pop()
+			order = append(order, v)
+		}
+		assert.Equal(t, []string{"a", "c", "d"}, order)
+		assert.False(t, a.Queued())
+		assert.False(t, c.Queued())
+
+		t.Log("Should reject handles from another queue")
+		other := generic.NewPriorityQueue[string, int]()
+		e := other.Push("e", 1)
+		assert.False(t, pq.Remove(e))
+		assert.True(t, e.Queued())
+	})
+
+	t.Run("comparator", func(t *testing.T) {
+		t.Parallel()
+
+		pq := generic.NewPriorityQueueFunc[string](func(a, b float64) int { return cmp.Compare(b, a) })
+		pq.Push("x", 0.5)
+		pq.Push("y", 2.5)
+		v, p, _ := pq.Pop()
+		assert.Equal(t, "y", v)
+		assert.Equal(t, 2.5, p)
+	})
+}
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	s := []int{7, 3, 9, 1, 4, 9, 2}
+
+	assert.Equal(t, []int{9, 9, 7}, generic.TopK(s, 3, cmp.Compare[int]))
+	assert.Equal(t, []int{1, 2, 3}, generic.BottomK(s, 3, cmp.Compare[int]))
+
+	t.Log("Should not modify the input")
+	assert.Equal(t, []int{7, 3, 9, 1, 4, 9, 2}, s)
+
+	t.Log("A k beyond the length should return everything sorted")
+	assert.Equal(t, []int{1, 2, 3, 4, 7, 9, 9}, generic.BottomK(s, 20, cmp.Compare[int]))
+	assert.Nil(t, generic.TopK(s, 0, cmp.Compare[int]))
+	assert.Nil(t, generic.TopK([]int{}, 3, cmp.Compare[int]))
+
+	words := []string{"ccc", "a", "bb", "dddd"}
+	assert.Equal(t, []string{"dddd", "ccc"}, generic.TopK(words, 2, byLength))
+}
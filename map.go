@@ -1,5 +1,20 @@
 package generic
 
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrKeyCollision is returned, wrapped, by ErrorOnCollision and by
+// IndexBy when two elements have the same key
+var ErrKeyCollision = errors.New("key collision")
+
+// ErrDuplicateValue is returned, wrapped, by InvertMap when two keys
+// have the same value
+var ErrDuplicateValue = errors.New("duplicate value")
+
 // Keys returns the map keys as a slice
 func Keys[K comparable, V any](m map[K]V) []K {
 	slice := make([]K, 0, len(m))
@@ -36,13 +51,51 @@ func MissingKeys[K comparable, V any](a, b map[K]V) []K {
 	return onlyA
 }
 
+// SortedKeys returns the map keys as a sorted slice
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	slice := Keys(m)
+	slices.Sort(slice)
+	return slice
+}
+
+// SortedKeysFunc returns the map keys as a slice ordered by cmp
+// as per slices.SortFunc
+func SortedKeysFunc[K comparable, V any](m map[K]V, cmp func(a, b K) int) []K {
+	slice := Keys(m)
+	slices.SortFunc(slice, cmp)
+	return slice
+}
+
+// ValuesByKey returns the map values as a slice ordered by
+// their sorted keys
+func ValuesByKey[K cmp.Ordered, V any](m map[K]V) []V {
+	keys := SortedKeys(m)
+	slice := make([]V, len(keys))
+	for i, k := range keys {
+		slice[i] = m[k]
+	}
+	return slice
+}
+
+// SortedCompareKeys is CompareKeys with both results sorted
+func SortedCompareKeys[K cmp.Ordered, V any](a, b map[K]V) ([]K, []K) {
+	return SortedMissingKeys(a, b), SortedMissingKeys(b, a)
+}
+
+// SortedMissingKeys is MissingKeys with the result sorted
+func SortedMissingKeys[K cmp.Ordered, V any](a, b map[K]V) []K {
+	onlyA := MissingKeys(a, b)
+	slices.Sort(onlyA)
+	return onlyA
+}
+
 // EqualKeys checks if two maps have exactly the same keys.
 // Returns true if both maps contain the same set of keys, regardless of values.
 func EqualKeys[K comparable, V any](a, b map[K]V) bool {
 	if len(a) != len(b) {
 		return false
 	}
-	// We don't need to check if there are extras in b not in a because 
+	// We don't need to check if there are extras in b not in a because
 	// we checked that there are an equal number of keys so all we have
 	// to check is that all of a is in b
 	for k := range a {
@@ -97,6 +150,101 @@ func Merge[K comparable, V any](a, b map[K]V) map[K]V {
 	return a
 }
 
+// MergeWith copies b onto a, calling resolve to pick the value for
+// any common keys: a is modified and returned. If a is nil, a copy
+// of b is returned.
+func MergeWith[K comparable, V any](a, b map[K]V, resolve func(k K, av, bv V) V) map[K]V {
+	if a == nil {
+		return CopyMap(b)
+	}
+	for k, bv := range b {
+		if av, ok := a[k]; ok {
+			a[k] = resolve(k, av, bv)
+		} else {
+			a[k] = bv
+		}
+	}
+	return a
+}
+
+// MergeConflict describes a key that was changed differently by
+// both sides of a three-way merge. The In fields report whether the
+// key was present in each map.
+type MergeConflict[K comparable, V any] struct {
+	Key      K
+	Base     V
+	Ours     V
+	Theirs   V
+	InBase   bool
+	InOurs   bool
+	InTheirs bool
+}
+
+// Merge3 performs a three-way merge of ours and theirs, both of which
+// are derived from base. Changes (including additions and deletions)
+// made on only one side are applied. Keys changed differently on both
+// sides keep the value from ours and are returned as conflicts, in no
+// particular order. Like Merge, ours is modified and returned. If ours
+// is nil, a new map is returned.
+func Merge3[K comparable, V comparable](base, ours, theirs map[K]V) (map[K]V, []MergeConflict[K, V]) {
+	return Merge3Func(base, ours, theirs, func(a, b V) bool {
+		return a == b
+	})
+}
+
+// Merge3Func is Merge3 for values that are not comparable: equal
+// reports whether two values are the same.
+func Merge3Func[K comparable, V any](base, ours, theirs map[K]V, equal func(V, V) bool) (map[K]V, []MergeConflict[K, V]) {
+	if ours == nil {
+		ours = make(map[K]V)
+	}
+	same := func(aok bool, av V, bok bool, bv V) bool {
+		if aok != bok {
+			return false
+		}
+		return !aok || equal(av, bv)
+	}
+	var conflicts []MergeConflict[K, V]
+	merge := func(k K) {
+		bv, inBase := base[k]
+		ov, inOurs := ours[k]
+		tv, inTheirs := theirs[k]
+		switch {
+		case same(inOurs, ov, inTheirs, tv):
+			// both sides agree
+		case same(inBase, bv, inOurs, ov):
+			// only theirs changed
+			if inTheirs {
+				ours[k] = tv
+			} else {
+				delete(ours, k)
+			}
+		case same(inBase, bv, inTheirs, tv):
+			// only ours changed
+		default:
+			conflicts = append(conflicts, MergeConflict[K, V]{
+				Key:      k,
+				Base:     bv,
+				Ours:     ov,
+				Theirs:   tv,
+				InBase:   inBase,
+				InOurs:   inOurs,
+				InTheirs: inTheirs,
+			})
+		}
+	}
+	// Keys that are only in ours were added by ours and need no action
+	for k := range base {
+		merge(k)
+	}
+	for k := range theirs {
+		if _, ok := base[k]; !ok {
+			merge(k)
+		}
+	}
+	return ours, conflicts
+}
+
 // AllKeys returns true if all keys in the map satisfy the given filter function.
 // Returns true for empty maps (vacuous truth).
 func AllKeys[K comparable, V any](m map[K]V, filter func(K) bool) bool {
@@ -140,3 +288,137 @@ func AnyValue[K comparable, V any](m map[K]V, filter func(V) bool) bool {
 	}
 	return false
 }
+
+// TransformMapValues returns a new map with each value converted by cast.
+// A nil map transforms to nil.
+func TransformMapValues[K comparable, V any, U any](m map[K]V, cast func(V) U) map[K]U {
+	if m == nil {
+		return nil
+	}
+	newM := make(map[K]U, len(m))
+	for k, v := range m {
+		newM[k] = cast(v)
+	}
+	return newM
+}
+
+// TransformMapKeys returns a new map with each key converted by cast.
+// When two keys convert to the same new key, resolve is called with the
+// new key, the value already stored, and the incoming value to decide
+// which value to keep. Pass ErrorOnCollision or a function that combines
+// the values. Keys are visited in random map order, so use
+// TransformMapKeysSorted with FirstWins or LastWins, or with any resolve
+// that depends on order, for a reproducible result. If resolve returns an
+// error, TransformMapKeys stops and returns nil and that error.
+func TransformMapKeys[K comparable, K2 comparable, V any](m map[K]V, cast func(K) K2, resolve func(k K2, existing, incoming V) (V, error)) (map[K2]V, error) {
+	if m == nil {
+		return nil, nil
+	}
+	newM := make(map[K2]V, len(m))
+	for k, v := range m {
+		if err := storeResolved(newM, cast(k), v, resolve); err != nil {
+			return nil, err
+		}
+	}
+	return newM, nil
+}
+
+// TransformMapKeysSorted is TransformMapKeys visiting the keys of m in
+// sorted order, so that for colliding keys "existing" is the value of the
+// smaller original key and the result is reproducible
+func TransformMapKeysSorted[K cmp.Ordered, K2 comparable, V any](m map[K]V, cast func(K) K2, resolve func(k K2, existing, incoming V) (V, error)) (map[K2]V, error) {
+	return TransformMapKeysSortedFunc(m, cast, resolve, cmp.Compare[K])
+}
+
+// TransformMapKeysSortedFunc is TransformMapKeysSorted with a comparator
+func TransformMapKeysSortedFunc[K comparable, K2 comparable, V any](m map[K]V, cast func(K) K2, resolve func(k K2, existing, incoming V) (V, error), cmp func(a, b K) int) (map[K2]V, error) {
+	if m == nil {
+		return nil, nil
+	}
+	newM := make(map[K2]V, len(m))
+	for _, k := range SortedKeysFunc(m, cmp) {
+		if err := storeResolved(newM, cast(k), m[k], resolve); err != nil {
+			return nil, err
+		}
+	}
+	return newM, nil
+}
+
+func storeResolved[K comparable, V any](m map[K]V, k K, v V, resolve func(k K, existing, incoming V) (V, error)) error {
+	if existing, ok := m[k]; ok {
+		var err error
+		v, err = resolve(k, existing, v)
+		if err != nil {
+			return err
+		}
+	}
+	m[k] = v
+	return nil
+}
+
+// ErrorOnCollision is a collision policy for TransformMapKeys that fails
+// with ErrKeyCollision
+func ErrorOnCollision[K comparable, V any](k K, existing, incoming V) (V, error) {
+	return existing, fmt.Errorf("%w: %v", ErrKeyCollision, k)
+}
+
+// FirstWins is a collision policy for TransformMapKeysSorted that keeps
+// the value of the first original key in sorted order
+func FirstWins[K comparable, V any](k K, existing, incoming V) (V, error) {
+	return existing, nil
+}
+
+// LastWins is a collision policy for TransformMapKeysSorted that keeps
+// the value of the last original key in sorted order
+func LastWins[K comparable, V any](k K, existing, incoming V) (V, error) {
+	return incoming, nil
+}
+
+// FilterMap returns a new map containing only entries for which filter
+// returns true. Like FilterSlice, nil is returned if nothing matches.
+func FilterMap[K comparable, V any](m map[K]V, filter func(K, V) bool) map[K]V {
+	result := make(map[K]V)
+	for k, v := range m {
+		if filter(k, v) {
+			result[k] = v
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// InvertMap returns a new map from values to keys. If two keys have the
+// same value, nil and an error wrapping ErrDuplicateValue are returned.
+func InvertMap[K comparable, V comparable](m map[K]V) (map[V]K, error) {
+	inverted := make(map[V]K, len(m))
+	for k, v := range m {
+		if _, ok := inverted[v]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateValue, v)
+		}
+		inverted[v] = k
+	}
+	return inverted, nil
+}
+
+// MapToSlice returns a slice with one element per map entry, converted
+// by cast. The order is random; sort the result or use SortedKeys if a
+// stable order is needed.
+func MapToSlice[K comparable, V any, T any](m map[K]V, cast func(K, V) T) []T {
+	slice := make([]T, 0, len(m))
+	for k, v := range m {
+		slice = append(slice, cast(k, v))
+	}
+	return slice
+}
+
+// SliceToMap returns a map of the slice elements keyed by the result of
+// key. If more than one element has the same key, the last one wins.
+func SliceToMap[T any, K comparable](slice []T, key func(T) K) map[K]T {
+	m := make(map[K]T, len(slice))
+	for _, item := range slice {
+		m[key(item)] = item
+	}
+	return m
+}
@@ -3,10 +3,12 @@ package generic_test
 // This file generated with Claude 3.7 Sonnet
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/singlestore-labs/generic"
 )
@@ -726,3 +728,318 @@ func TestAnyValue(t *testing.T) {
 		assert.False(t, result)
 	})
 }
+
+func TestSortedKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sorts keys", func(t *testing.T) {
+		t.Parallel()
+
+		m := map[string]int{"c": 1, "a": 2, "b": 3}
+
+		t.Log("Should return keys in ascending order")
+		assert.Equal(t, []string{"a", "b", "c"}, generic.SortedKeys(m))
+	})
+
+	t.Run("handles empty map", func(t *testing.T) {
+		t.Parallel()
+
+		t.Log("Should return empty slice for empty map")
+		assert.Empty(t, generic.SortedKeys(map[int]string{}))
+	})
+
+	t.Run("sorts with comparator", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct{ a, b int }
+		m := map[key]bool{{2, 1}: true, {1, 2}: true, {1, 1}: true}
+		keys := generic.SortedKeysFunc(m, func(x, y key) int {
+			if x.a != y.a {
+				return x.a - y.a
+			}
+			return x.b - y.b
+		})
+
+		t.Log("Should return keys in comparator order")
+		assert.Equal(t, []key{{1, 1}, {1, 2}, {2, 1}}, keys)
+	})
+}
+
+func TestValuesByKey(t *testing.T) {
+	t.Parallel()
+
+	m := map[int]string{3: "c", 1: "a", 2: "b", 4: "a"}
+
+	t.Log("Should return values ordered by their keys")
+	assert.Equal(t, []string{"a", "b", "c", "a"}, generic.ValuesByKey(m))
+	assert.Empty(t, generic.ValuesByKey(map[int]string(nil)))
+}
+
+func TestSortedCompareKeys(t *testing.T) {
+	t.Parallel()
+
+	a := map[string]int{"z": 1, "b": 2, "m": 3, "shared": 4}
+	b := map[string]int{"y": 1, "c": 2, "shared": 4}
+
+	onlyA, onlyB := generic.SortedCompareKeys(a, b)
+
+	t.Log("Should return sorted keys unique to each map")
+	assert.Equal(t, []string{"b", "m", "z"}, onlyA)
+	assert.Equal(t, []string{"c", "y"}, onlyB)
+	assert.Equal(t, []string{"b", "m", "z"}, generic.SortedMissingKeys(a, b))
+}
+
+func TestMergeWith(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolves common keys", func(t *testing.T) {
+		t.Parallel()
+
+		a := map[string]int{"a": 1, "b": 2}
+		b := map[string]int{"b": 20, "c": 30}
+
+		result := generic.MergeWith(a, b, func(k string, av, bv int) int { return av + bv })
+
+		t.Log("Should call resolve only for common keys")
+		assert.Equal(t, map[string]int{"a": 1, "b": 22, "c": 30}, result)
+		assert.Equal(t, a, result) // MergeWith returns a
+	})
+
+	t.Run("handles nil first map", func(t *testing.T) {
+		t.Parallel()
+
+		b := map[string]int{"x": 10}
+		result := generic.MergeWith(nil, b, func(k string, av, bv int) int { panic("not called") })
+
+		t.Log("Should return a copy of b when a is nil")
+		assert.Equal(t, b, result)
+		result["x"] = 11
+		assert.Equal(t, 10, b["x"])
+	})
+}
+
+func TestMerge3(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies non-conflicting changes", func(t *testing.T) {
+		t.Parallel()
+
+		base := map[string]string{"keep": "1", "ours": "1", "theirs": "1", "delOurs": "1", "delTheirs": "1", "same": "1"}
+		ours := map[string]string{"keep": "1", "ours": "2", "theirs": "1", "delTheirs": "1", "same": "2", "addOurs": "1"}
+		theirs := map[string]string{"keep": "1", "ours": "1", "theirs": "2", "delOurs": "1", "same": "2", "addTheirs": "1"}
+
+		result, conflicts := generic.Merge3(base, ours, theirs)
+
+		t.Log("Should take each side's edits and report no conflicts")
+		assert.Empty(t, conflicts)
+		assert.Equal(t, map[string]string{
+			"keep":      "1",
+			"ours":      "2",
+			"theirs":    "2",
+			"same":      "2",
+			"addOurs":   "1",
+			"addTheirs": "1",
+		}, result)
+		assert.Equal(t, ours, result) // Merge3 returns ours
+	})
+
+	t.Run("reports conflicts", func(t *testing.T) {
+		t.Parallel()
+
+		base := map[string]int{"edit": 1, "delete": 1}
+		ours := map[string]int{"edit": 2, "add": 1}
+		theirs := map[string]int{"edit": 3, "delete": 2, "add": 2}
+
+		result, conflicts := generic.Merge3(base, ours, theirs)
+
+		t.Log("Should keep ours for conflicting keys")
+		assert.Equal(t, map[string]int{"edit": 2, "add": 1}, result)
+		assert.ElementsMatch(t, []generic.MergeConflict[string, int]{
+			{Key: "edit", Base: 1, Ours: 2, Theirs: 3, InBase: true, InOurs: true, InTheirs: true},
+			{Key: "delete", Base: 1, Theirs: 2, InBase: true, InTheirs: true},
+			{Key: "add", Ours: 1, Theirs: 2, InOurs: true, InTheirs: true},
+		}, conflicts)
+	})
+
+	t.Run("handles nil ours", func(t *testing.T) {
+		t.Parallel()
+
+		result, conflicts := generic.Merge3(nil, nil, map[string]int{"a": 1})
+
+		t.Log("Should return a new map when ours is nil")
+		assert.Empty(t, conflicts)
+		assert.Equal(t, map[string]int{"a": 1}, result)
+	})
+
+	t.Run("equality function", func(t *testing.T) {
+		t.Parallel()
+
+		base := map[string][]int{"a": {1}}
+		ours := map[string][]int{"a": {1}}
+		theirs := map[string][]int{"a": {2}}
+
+		result, conflicts := generic.Merge3Func(base, ours, theirs, func(a, b []int) bool {
+			return len(a) == len(b) && a[0] == b[0]
+		})
+
+		t.Log("Should use the equality function for non-comparable values")
+		assert.Empty(t, conflicts)
+		assert.Equal(t, map[string][]int{"a": {2}}, result)
+	})
+}
+
+func TestTransformMapValues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts values", func(t *testing.T) {
+		t.Parallel()
+
+		m := map[string]int{"a": 1, "b": 2}
+		result := generic.TransformMapValues(m, strconv.Itoa)
+
+		t.Log("Should keep keys and convert values")
+		assert.Equal(t, map[string]string{"a": "1", "b": "2"}, result)
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		t.Parallel()
+
+		var m map[string]int
+		assert.Nil(t, generic.TransformMapValues(m, strconv.Itoa))
+	})
+}
+
+func TestTransformMapKeys(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"a": 1, "A": 2, "b": 3}
+
+	t.Run("no collisions", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TransformMapKeys(map[int]string{1: "a", 2: "b"}, strconv.Itoa, generic.ErrorOnCollision[string, string])
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"1": "a", "2": "b"}, result)
+	})
+
+	t.Run("error on collision", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TransformMapKeys(m, strings.ToLower, generic.ErrorOnCollision[string, int])
+
+		t.Log("Should fail when two keys convert to the same key")
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, generic.ErrKeyCollision)
+		assert.Contains(t, err.Error(), ": a")
+	})
+
+	t.Run("first and last wins in sorted order", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := generic.TransformMapKeysSorted(m, strings.ToLower, generic.FirstWins[string, int])
+		require.NoError(t, err)
+		last, err := generic.TransformMapKeysSorted(m, strings.ToLower, generic.LastWins[string, int])
+		require.NoError(t, err)
+
+		t.Log("Should keep the value of the smallest or largest original key")
+		assert.Equal(t, map[string]int{"a": 2, "b": 3}, first)
+		assert.Equal(t, map[string]int{"a": 1, "b": 3}, last)
+	})
+
+	t.Run("sorted with a comparator", func(t *testing.T) {
+		t.Parallel()
+
+		reverse := func(a, b string) int { return strings.Compare(b, a) }
+		first, err := generic.TransformMapKeysSortedFunc(m, strings.ToLower, generic.FirstWins[string, int], reverse)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "b": 3}, first)
+
+		var collisions []int
+		_, err = generic.TransformMapKeysSorted(m, strings.ToLower, func(k string, existing, incoming int) (int, error) {
+			collisions = append(collisions, existing, incoming)
+			return incoming, nil
+		})
+		require.NoError(t, err)
+		t.Log("Should pass values to resolve in key order")
+		assert.Equal(t, []int{2, 1}, collisions)
+
+		result, err := generic.TransformMapKeysSorted(map[string]int(nil), strings.ToLower, generic.LastWins[string, int])
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("combine", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TransformMapKeys(m, strings.ToLower, func(k string, existing, incoming int) (int, error) {
+			return existing + incoming, nil
+		})
+
+		t.Log("Should combine colliding values")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 3, "b": 3}, result)
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TransformMapKeys(map[string]int(nil), strings.ToLower, generic.LastWins[string, int])
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestFilterMap(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	result := generic.FilterMap(m, func(k string, v int) bool { return k != "a" && v%2 == 1 })
+	assert.Equal(t, map[string]int{"c": 3}, result)
+
+	t.Log("Should return nil for no matches")
+	assert.Nil(t, generic.FilterMap(m, func(string, int) bool { return false }))
+}
+
+func TestInvertMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inverts unique values", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.InvertMap(map[string]int{"a": 1, "b": 2})
+		require.NoError(t, err)
+		assert.Equal(t, map[int]string{1: "a", 2: "b"}, result)
+	})
+
+	t.Run("detects duplicate values", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.InvertMap(map[string]int{"a": 1, "b": 1})
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, generic.ErrDuplicateValue)
+	})
+}
+
+func TestMapToSlice(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"a": 1, "b": 2}
+	result := generic.MapToSlice(m, func(k string, v int) string { return k + "=" + strconv.Itoa(v) })
+	assert.ElementsMatch(t, []string{"a=1", "b=2"}, result)
+	assert.Empty(t, generic.MapToSlice(map[string]int{}, func(k string, v int) string { return k }))
+}
+
+func TestSliceToMap(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		ID   int
+		Name string
+	}
+	rows := []row{{1, "a"}, {2, "b"}, {1, "c"}}
+	result := generic.SliceToMap(rows, func(r row) int { return r.ID })
+
+	t.Log("Should key elements by the extractor with the last duplicate winning")
+	assert.Equal(t, map[int]row{1: {1, "c"}, 2: {2, "b"}}, result)
+}
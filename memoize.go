@@ -0,0 +1,164 @@
+package generic
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrGroupGoexit is returned to callers waiting on a Group call whose
+// function called runtime.Goexit, such as through t.FailNow
+var ErrGroupGoexit = errors.New("group call exited with runtime.Goexit")
+
+// Group collapses concurrent calls for the same key into one call, in the
+// style of golang.org/x/sync/singleflight, and optionally caches the
+// results. The zero value is ready to use and does not cache. A Group
+// must not be copied after first use.
+//
+// The cache is never used while the Group's own lock is held, so a
+// cache OnEvict callback may call back into the Group.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*groupCall[V]
+	cache *Cache[K, V] // nil if results are not cached
+}
+
+type groupCall[V any] struct {
+	done       chan struct{}
+	value      V
+	err        error
+	panicked   bool
+	panicValue any
+}
+
+// NewGroup returns a Group that caches successful results for ttl. A ttl
+// of zero means results are not cached. Expired results are dropped as
+// new results are cached.
+func NewGroup[K comparable, V any](ttl time.Duration) *Group[K, V] {
+	if ttl <= 0 {
+		return &Group[K, V]{}
+	}
+	return NewGroupWithCache(NewCache(CacheOptions[K, V]{TTL: ttl}))
+}
+
+// NewGroupWithCache returns a Group that stores successful results in
+// cache, so its capacity, eviction policy, TTL, and clock all apply
+func NewGroupWithCache[K comparable, V any](cache *Cache[K, V]) *Group[K, V] {
+	return &Group[K, V]{cache: cache}
+}
+
+// Do returns the cached result for k if there is one. Otherwise it calls
+// fn(k), unless a call for k is already in flight in which case it waits
+// for that call and returns its result. Errors are returned to every
+// waiter but are not cached. If fn panics, every waiter panics with the
+// same value. If fn calls runtime.Goexit, the calling goroutine exits
+// and every waiter gets ErrGroupGoexit.
+func (g *Group[K, V]) Do(k K, fn func(K) (V, error)) (V, error) {
+	if g.cache != nil {
+		if v, ok := g.cache.Get(k); ok {
+			return v, nil
+		}
+	}
+	g.mu.Lock()
+	if c, ok := g.calls[k]; ok {
+		g.mu.Unlock()
+		<-c.done
+		return c.result()
+	}
+	if g.calls == nil {
+		g.calls = make(map[K]*groupCall[V])
+	}
+	c := &groupCall[V]{done: make(chan struct{})}
+	g.calls[k] = c
+	g.mu.Unlock()
+
+	g.run(k, c, fn)
+	return c.result()
+}
+
+func (g *Group[K, V]) run(k K, c *groupCall[V], fn func(K) (V, error)) {
+	normalReturn := false
+	defer func() {
+		if !normalReturn {
+			// recover returns nil after runtime.Goexit
+			if r := recover(); r != nil {
+				c.panicked = true
+				c.panicValue = r
+			} else {
+				c.err = ErrGroupGoexit
+			}
+		}
+		g.finish(k, c, normalReturn && c.err == nil)
+	}()
+	c.value, c.err = fn(k)
+	normalReturn = true
+}
+
+// finish caches a successful result and releases the waiters. The call
+// stays registered until its result is cached so that a Do arriving in
+// between waits for it instead of starting another call.
+func (g *Group[K, V]) finish(k K, c *groupCall[V], succeeded bool) {
+	cached := false
+	if succeeded && g.cache != nil {
+		g.mu.Lock()
+		current := g.calls[k] == c
+		g.mu.Unlock()
+		// A forgotten call must not cache its result
+		if current {
+			g.cache.Set(k, c.value)
+			cached = true
+		}
+	}
+	g.mu.Lock()
+	forgotten := g.calls[k] != c
+	if !forgotten {
+		delete(g.calls, k)
+	}
+	g.mu.Unlock()
+	if forgotten && cached {
+		// Forget ran while the result was being cached. Dropping the
+		// entry may also drop a newer result, which only costs a call.
+		g.cache.Delete(k)
+	}
+	close(c.done)
+}
+
+func (c *groupCall[V]) result() (V, error) {
+	if c.panicked {
+		panic(c.panicValue)
+	}
+	return c.value, c.err
+}
+
+// Forget drops any cached result for k. A call for k that is in flight
+// still completes for its existing waiters, but later calls to Do start a
+// new call instead of waiting for it.
+func (g *Group[K, V]) Forget(k K) {
+	g.mu.Lock()
+	delete(g.calls, k)
+	g.mu.Unlock()
+	if g.cache != nil {
+		g.cache.Delete(k)
+	}
+}
+
+// Memoize wraps fn so that successful results are cached forever and
+// concurrent calls for the same key share one call to fn. The cache is
+// unbounded, so use MemoizeFor or a Group with a bounded Cache when the
+// keys are not from a small fixed set.
+func Memoize[K comparable, V any](fn func(K) (V, error)) func(K) (V, error) {
+	return memoizeWith(NewGroupWithCache(NewCache(CacheOptions[K, V]{})), fn)
+}
+
+// MemoizeFor is Memoize with results cached for ttl. Expired results are
+// dropped as new results are cached, so memory is bounded by the number
+// of distinct keys looked up within ttl.
+func MemoizeFor[K comparable, V any](fn func(K) (V, error), ttl time.Duration) func(K) (V, error) {
+	return memoizeWith(NewGroup[K, V](ttl), fn)
+}
+
+func memoizeWith[K comparable, V any](g *Group[K, V], fn func(K) (V, error)) func(K) (V, error) {
+	return func(k K) (V, error) {
+		return g.Do(k, fn)
+	}
+}
@@ -0,0 +1,281 @@
+package generic_test
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestGroupCollapsesCalls(t *testing.T) {
+	t.Parallel()
+
+	// A caching group means a waiter that arrives after the call has
+	// finished hits the cache instead of calling fn again
+	g := generic.NewGroup[string, int](time.Hour)
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(k string) (int, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return len(k), nil
+	}
+
+	const waiters = 5
+	var wg sync.WaitGroup
+	results := make([]int, waiters)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		v, err := g.Do("abc", fn)
+		assert.NoError(t, err)
+		results[0] = v
+	}()
+	<-started
+	for i := 1; i < waiters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := g.Do("abc", fn)
+			assert.NoError(t, err)
+			results[i] = v
+		}(i)
+	}
+	close(release)
+	wg.Wait()
+
+	t.Log("Concurrent callers should share one call")
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []int{3, 3, 3, 3, 3}, results)
+}
+
+func TestGroupZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var g generic.Group[string, int]
+	var calls int
+	fn := func(k string) (int, error) {
+		calls++
+		return len(k), nil
+	}
+	_, _ = g.Do("abc", fn)
+	v, err := g.Do("abc", fn)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	t.Log("The zero value should not cache")
+	assert.Equal(t, 2, calls)
+}
+
+func TestGroupCaching(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	g := generic.NewGroupWithCache(generic.NewCache(generic.CacheOptions[int, int]{
+		TTL: time.Minute,
+		Now: clock.Now,
+	}))
+	var calls int
+	fn := func(k int) (int, error) {
+		calls++
+		return k * 2, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		v, err := g.Do(21, fn)
+		require.NoError(t, err)
+		assert.Equal(t, 42, v)
+	}
+	assert.Equal(t, 1, calls)
+
+	t.Log("Should call again once the result expires")
+	clock.Advance(time.Minute)
+	_, _ = g.Do(21, fn)
+	assert.Equal(t, 2, calls)
+
+	t.Log("Should call again after Forget")
+	g.Forget(21)
+	_, _ = g.Do(21, fn)
+	assert.Equal(t, 3, calls)
+}
+
+func TestGroupDropsExpiredResults(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := generic.NewCache(generic.CacheOptions[int, int]{TTL: time.Minute, Now: clock.Now})
+	g := generic.NewGroupWithCache(cache)
+	fn := func(k int) (int, error) { return k, nil }
+	for i := 0; i < 1000; i++ {
+		_, _ = g.Do(i, fn)
+	}
+	clock.Advance(time.Minute)
+	_, _ = g.Do(-1, fn)
+
+	t.Log("Results for keys that are never looked up again should not accumulate")
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestGroupErrors(t *testing.T) {
+	t.Parallel()
+
+	g := generic.NewGroup[string, int](time.Hour)
+	errBoom := errors.New("boom")
+	var calls int
+	fail := true
+	fn := func(string) (int, error) {
+		calls++
+		if fail {
+			return 0, errBoom
+		}
+		return 1, nil
+	}
+
+	_, err := g.Do("k", fn)
+	assert.ErrorIs(t, err, errBoom)
+
+	t.Log("Errors should not be cached")
+	fail = false
+	v, err := g.Do("k", fn)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGroupPanic(t *testing.T) {
+	t.Parallel()
+
+	var g generic.Group[int, int]
+	assert.PanicsWithValue(t, "bad key", func() {
+		_, _ = g.Do(1, func(int) (int, error) { panic("bad key") })
+	})
+
+	t.Log("A panicking call should not block later calls")
+	v, err := g.Do(1, func(k int) (int, error) { return k, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestGroupForgetInFlight(t *testing.T) {
+	t.Parallel()
+
+	g := generic.NewGroup[string, string](time.Hour)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan string)
+	go func() {
+		v, _ := g.Do("k", func(string) (string, error) {
+			close(started)
+			<-release
+			return "old", nil
+		})
+		done <- v
+	}()
+	<-started
+	g.Forget("k")
+
+	v, err := g.Do("k", func(string) (string, error) { return "new", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "new", v)
+
+	close(release)
+	assert.Equal(t, "old", <-done)
+
+	t.Log("The forgotten call should not replace the cached result")
+	v, _ = g.Do("k", func(string) (string, error) { return "unused", nil })
+	assert.Equal(t, "new", v)
+}
+
+func TestGroupGoexit(t *testing.T) {
+	t.Parallel()
+
+	g := generic.NewGroup[string, int](time.Hour)
+	returned := false
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		_, _ = g.Do("k", func(string) (int, error) {
+			runtime.Goexit()
+			return 1, nil
+		})
+		returned = true
+	}()
+	<-exited
+	assert.False(t, returned)
+
+	t.Log("A call that exited should not be cached as a success")
+	var calls int
+	v, err := g.Do("k", func(string) (int, error) {
+		calls++
+		return 2, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGroupEvictCallback(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var g *generic.Group[string, int]
+	var evicted atomic.Int32
+	g = generic.NewGroupWithCache(generic.NewCache(generic.CacheOptions[string, int]{
+		TTL: time.Minute,
+		Now: clock.Now,
+		OnEvict: func(k string, _ int, _ generic.EvictionReason) {
+			evicted.Add(1)
+			g.Forget(k)
+		},
+	}))
+	fn := func(k string) (int, error) { return len(k), nil }
+
+	_, _ = g.Do("k", fn)
+	clock.Advance(time.Minute)
+
+	t.Log("An OnEvict that uses the group should not deadlock")
+	v, err := g.Do("k", fn)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.Positive(t, evicted.Load())
+}
+
+func TestMemoize(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	square := generic.Memoize(func(k int) (int, error) {
+		calls++
+		return k * k, nil
+	})
+	for i := 0; i < 3; i++ {
+		v, err := square(4)
+		require.NoError(t, err)
+		assert.Equal(t, 16, v)
+	}
+	_, _ = square(5)
+	assert.Equal(t, 2, calls)
+
+	t.Run("for a duration", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		f := generic.MemoizeFor(func(k string) (string, error) {
+			calls.Add(1)
+			return k, nil
+		}, time.Hour)
+		_, _ = f("a")
+		_, _ = f("a")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
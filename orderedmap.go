@@ -0,0 +1,354 @@
+package generic
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+)
+
+// OrderedMap is a map that remembers the order in which keys were
+// first inserted. Setting an existing key updates its value without
+// changing its position. The zero value is an empty map ready to use.
+// Read methods may be called on a nil *OrderedMap.
+//
+// An OrderedMap must not be copied after first use; use Copy instead.
+// It is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	m    map[K]*orderedMapEntry[K, V]
+	root orderedMapEntry[K, V] // sentinel: root.next is the first entry
+}
+
+type orderedMapEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *orderedMapEntry[K, V]
+	deleted    bool
+}
+
+// NewOrderedMap returns an empty OrderedMap
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+// OrderedMapFromMap returns an OrderedMap with the contents of m
+// inserted in sorted key order
+func OrderedMapFromMap[K cmp.Ordered, V any](m map[K]V) *OrderedMap[K, V] {
+	om := NewOrderedMap[K, V]()
+	for _, k := range SortedKeys(m) {
+		om.Set(k, m[k])
+	}
+	return om
+}
+
+func (om *OrderedMap[K, V]) lazyInit() {
+	if om.m == nil {
+		om.m = make(map[K]*orderedMapEntry[K, V])
+		om.root.next = &om.root
+		om.root.prev = &om.root
+	}
+}
+
+func (om *OrderedMap[K, V]) insertBefore(e, at *orderedMapEntry[K, V]) {
+	e.prev = at.prev
+	e.next = at
+	at.prev.next = e
+	at.prev = e
+}
+
+// unlink removes e from the list but leaves e.next alone so that Range
+// can find its way back to the list from an entry deleted under it
+func (om *OrderedMap[K, V]) unlink(e *orderedMapEntry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+}
+
+// Set stores v under k. New keys are added at the back.
+func (om *OrderedMap[K, V]) Set(k K, v V) {
+	om.lazyInit()
+	if e, ok := om.m[k]; ok {
+		e.value = v
+		return
+	}
+	e := &orderedMapEntry[K, V]{key: k, value: v}
+	om.insertBefore(e, &om.root)
+	om.m[k] = e
+}
+
+// Get returns the value stored under k and whether it was present
+func (om *OrderedMap[K, V]) Get(k K) (V, bool) {
+	if om == nil {
+		var zero V
+		return zero, false
+	}
+	if e, ok := om.m[k]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has returns true if k is in the map
+func (om *OrderedMap[K, V]) Has(k K) bool {
+	if om == nil {
+		return false
+	}
+	_, ok := om.m[k]
+	return ok
+}
+
+// Delete removes k, returning true if it was present
+func (om *OrderedMap[K, V]) Delete(k K) bool {
+	e, ok := om.m[k]
+	if !ok {
+		return false
+	}
+	om.unlink(e)
+	e.deleted = true
+	delete(om.m, k)
+	return true
+}
+
+func (om *OrderedMap[K, V]) Len() int {
+	if om == nil {
+		return 0
+	}
+	return len(om.m)
+}
+
+// MoveToFront makes k the first key, returning false if k is not present
+func (om *OrderedMap[K, V]) MoveToFront(k K) bool {
+	e, ok := om.m[k]
+	if !ok {
+		return false
+	}
+	om.unlink(e)
+	om.insertBefore(e, om.root.next)
+	return true
+}
+
+// MoveToBack makes k the last key, returning false if k is not present
+func (om *OrderedMap[K, V]) MoveToBack(k K) bool {
+	e, ok := om.m[k]
+	if !ok {
+		return false
+	}
+	om.unlink(e)
+	om.insertBefore(e, &om.root)
+	return true
+}
+
+// Range calls f for each key and value in insertion order, stopping
+// if f returns false. As with a Go map, f may delete any key, and keys
+// deleted before they are reached are not visited. Keys added by f may
+// or may not be visited, and keys moved by f may be skipped or repeated.
+func (om *OrderedMap[K, V]) Range(f func(k K, v V) bool) {
+	if om == nil || om.m == nil {
+		return
+	}
+	for e := om.root.next; e != &om.root; {
+		next := e.next
+		if !f(e.key, e.value) {
+			return
+		}
+		// Deleted entries still point at the entry that followed them
+		for next.deleted {
+			next = next.next
+		}
+		e = next
+	}
+}
+
+// Keys returns the keys in insertion order
+func (om *OrderedMap[K, V]) Keys() []K {
+	slice := make([]K, 0, om.Len())
+	om.Range(func(k K, _ V) bool {
+		slice = append(slice, k)
+		return true
+	})
+	return slice
+}
+
+// Values returns the values in insertion order
+func (om *OrderedMap[K, V]) Values() []V {
+	slice := make([]V, 0, om.Len())
+	om.Range(func(_ K, v V) bool {
+		slice = append(slice, v)
+		return true
+	})
+	return slice
+}
+
+// Copy returns a new OrderedMap with the same contents and order
+func (om *OrderedMap[K, V]) Copy() *OrderedMap[K, V] {
+	c := NewOrderedMap[K, V]()
+	om.Range(func(k K, v V) bool {
+		c.Set(k, v)
+		return true
+	})
+	return c
+}
+
+// ToMap returns the contents as a plain map for use with the
+// other map functions. A new map is always returned.
+func (om *OrderedMap[K, V]) ToMap() map[K]V {
+	m := make(map[K]V, om.Len())
+	om.Range(func(k K, v V) bool {
+		m[k] = v
+		return true
+	})
+	return m
+}
+
+// EqualOrderedKeys checks if two ordered maps have exactly the same keys,
+// regardless of order and values.
+func EqualOrderedKeys[K comparable, V any](a, b *OrderedMap[K, V]) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	equal := true
+	a.Range(func(k K, _ V) bool {
+		equal = b.Has(k)
+		return equal
+	})
+	return equal
+}
+
+// CompareOrderedKeys returns slice of keys that are only in a
+// and a slice of keys that are only in b, each in insertion order.
+func CompareOrderedKeys[K comparable, V any](a, b *OrderedMap[K, V]) ([]K, []K) {
+	return MissingOrderedKeys(a, b), MissingOrderedKeys(b, a)
+}
+
+// MissingOrderedKeys returns the keys that are in a but not b
+// in the insertion order of a
+func MissingOrderedKeys[K comparable, V any](a, b *OrderedMap[K, V]) []K {
+	onlyA := make([]K, 0, a.Len())
+	a.Range(func(k K, _ V) bool {
+		if !b.Has(k) {
+			onlyA = append(onlyA, k)
+		}
+		return true
+	})
+	return onlyA
+}
+
+// MergeOrdered copies b onto a, overriding any common keys: a is
+// modified and returned. Common keys keep their position in a and new
+// keys are added at the back in the order of b. If a is nil, a copy of
+// b is returned.
+func MergeOrdered[K comparable, V any](a, b *OrderedMap[K, V]) *OrderedMap[K, V] {
+	if a == nil {
+		if b == nil {
+			return nil
+		}
+		return b.Copy()
+	}
+	b.Range(func(k K, v V) bool {
+		a.Set(k, v)
+		return true
+	})
+	return a
+}
+
+// MarshalJSON encodes the map as a JSON object with keys in insertion
+// order. Keys are encoded following the same rules encoding/json uses
+// for map keys.
+func (om *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	var err error
+	om.Range(func(k K, v V) bool {
+		var kb, vb []byte
+		kb, err = marshalJSONKey(k)
+		if err != nil {
+			return false
+		}
+		vb, err = json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(vb)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object, adding keys in the order
+// they appear. Existing contents are replaced.
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		// By convention, unmarshaling JSON null is a no-op
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("cannot unmarshal %v into OrderedMap: expected object", tok)
+	}
+	*om = OrderedMap[K, V]{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		ks, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("cannot unmarshal %v into OrderedMap: expected key", tok)
+		}
+		k, err := unmarshalJSONKey[K](ks)
+		if err != nil {
+			return err
+		}
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		om.Set(k, v)
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// marshalJSONKey encodes k as a quoted JSON object key by letting
+// encoding/json encode a single entry map
+func marshalJSONKey[K comparable](k K) ([]byte, error) {
+	b, err := json.Marshal(map[K]struct{}{k: {}})
+	if err != nil {
+		return nil, err
+	}
+	// b is {"key":{}}
+	return b[1 : len(b)-len(":{}}")], nil
+}
+
+// unmarshalJSONKey decodes an object key string into a K by letting
+// encoding/json decode a single entry map
+func unmarshalJSONKey[K comparable](s string) (K, error) {
+	q, err := json.Marshal(s)
+	if err != nil {
+		var zero K
+		return zero, err
+	}
+	var m map[K]struct{}
+	if err := json.Unmarshal([]byte("{"+string(q)+":{}}"), &m); err != nil {
+		var zero K
+		return zero, err
+	}
+	for k := range m {
+		return k, nil
+	}
+	var zero K
+	return zero, fmt.Errorf("cannot unmarshal key %q", s)
+}
@@ -0,0 +1,267 @@
+package generic_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("preserves insertion order", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[string, int]()
+		om.Set("c", 1)
+		om.Set("a", 2)
+		om.Set("b", 3)
+		om.Set("c", 4)
+
+		t.Log("Updating a key should not change its position")
+		assert.Equal(t, []string{"c", "a", "b"}, om.Keys())
+		assert.Equal(t, []int{4, 2, 3}, om.Values())
+		assert.Equal(t, 3, om.Len())
+
+		v, ok := om.Get("c")
+		assert.True(t, ok)
+		assert.Equal(t, 4, v)
+		_, ok = om.Get("x")
+		assert.False(t, ok)
+	})
+
+	t.Run("zero value and nil are usable", func(t *testing.T) {
+		t.Parallel()
+
+		var om generic.OrderedMap[string, int]
+		assert.Equal(t, 0, om.Len())
+		assert.Empty(t, om.Keys())
+		om.Set("a", 1)
+		assert.True(t, om.Has("a"))
+
+		var nilMap *generic.OrderedMap[string, int]
+		assert.Equal(t, 0, nilMap.Len())
+		assert.False(t, nilMap.Has("a"))
+		assert.Empty(t, nilMap.ToMap())
+	})
+
+	t.Run("delete and reinsert", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[string, int]()
+		om.Set("a", 1)
+		om.Set("b", 2)
+		om.Set("c", 3)
+
+		assert.True(t, om.Delete("a"))
+		assert.False(t, om.Delete("a"))
+		om.Set("a", 4)
+
+		t.Log("A deleted key should be reinserted at the back")
+		assert.Equal(t, []string{"b", "c", "a"}, om.Keys())
+	})
+
+	t.Run("move to front and back", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[int, string]()
+		for i := 1; i <= 4; i++ {
+			om.Set(i, "")
+		}
+
+		assert.True(t, om.MoveToFront(3))
+		assert.Equal(t, []int{3, 1, 2, 4}, om.Keys())
+		assert.True(t, om.MoveToBack(1))
+		assert.Equal(t, []int{3, 2, 4, 1}, om.Keys())
+		assert.False(t, om.MoveToFront(9))
+		assert.False(t, om.MoveToBack(9))
+	})
+
+	t.Run("range allows deleting current key", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[int, int]()
+		for i := 0; i < 5; i++ {
+			om.Set(i, i*i)
+		}
+
+		var seen []int
+		om.Range(func(k, v int) bool {
+			seen = append(seen, k)
+			if k%2 == 0 {
+				om.Delete(k)
+			}
+			return k < 3
+		})
+
+		t.Log("Should visit keys in order and stop when f returns false")
+		assert.Equal(t, []int{0, 1, 2, 3}, seen)
+		assert.Equal(t, []int{1, 3, 4}, om.Keys())
+	})
+
+	t.Run("range allows deleting other keys", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[string, int]()
+		for i, k := range []string{"a", "b", "c", "d", "e"} {
+			om.Set(k, i)
+		}
+
+		var seen []string
+		om.Range(func(k string, _ int) bool {
+			seen = append(seen, k)
+			switch k {
+			case "a":
+				om.Delete("b")
+			case "c":
+				t.Log("Deleting the current and next keys together should still continue")
+				om.Delete("c")
+				om.Delete("d")
+			}
+			return true
+		})
+
+		t.Log("Should not visit keys deleted before they are reached")
+		assert.Equal(t, []string{"a", "c", "e"}, seen)
+		assert.Equal(t, []string{"a", "e"}, om.Keys())
+	})
+
+	t.Run("copy is independent", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[string, int]()
+		om.Set("a", 1)
+		c := om.Copy()
+		c.Set("b", 2)
+
+		assert.Equal(t, []string{"a"}, om.Keys())
+		assert.Equal(t, []string{"a", "b"}, c.Keys())
+	})
+}
+
+func TestOrderedMapAdapters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("from and to plain maps", func(t *testing.T) {
+		t.Parallel()
+
+		m := map[string]int{"b": 2, "a": 1, "c": 3}
+		om := generic.OrderedMapFromMap(m)
+
+		t.Log("Should insert in sorted key order and convert back")
+		assert.Equal(t, []string{"a", "b", "c"}, om.Keys())
+		assert.Equal(t, m, om.ToMap())
+		assert.True(t, generic.EqualKeys(m, om.ToMap()))
+	})
+
+	t.Run("compare keys", func(t *testing.T) {
+		t.Parallel()
+
+		a := generic.NewOrderedMap[string, int]()
+		b := generic.NewOrderedMap[string, int]()
+		for _, k := range []string{"z", "shared", "a"} {
+			a.Set(k, 0)
+		}
+		for _, k := range []string{"y", "shared", "b"} {
+			b.Set(k, 0)
+		}
+
+		onlyA, onlyB := generic.CompareOrderedKeys(a, b)
+
+		t.Log("Should return missing keys in insertion order")
+		assert.Equal(t, []string{"z", "a"}, onlyA)
+		assert.Equal(t, []string{"y", "b"}, onlyB)
+		assert.False(t, generic.EqualOrderedKeys(a, b))
+		assert.True(t, generic.EqualOrderedKeys(a, a.Copy()))
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+
+		a := generic.NewOrderedMap[string, int]()
+		a.Set("a", 1)
+		a.Set("b", 2)
+		b := generic.NewOrderedMap[string, int]()
+		b.Set("c", 30)
+		b.Set("b", 20)
+
+		result := generic.MergeOrdered(a, b)
+
+		t.Log("Common keys keep their position and new keys are appended")
+		assert.Equal(t, []string{"a", "b", "c"}, result.Keys())
+		assert.Equal(t, []int{1, 20, 30}, result.Values())
+		assert.Same(t, a, result)
+
+		copied := generic.MergeOrdered(nil, b)
+		assert.Equal(t, b.Keys(), copied.Keys())
+		assert.NotSame(t, b, copied)
+	})
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trips in order", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[string, []int]()
+		om.Set("zeta", []int{1})
+		om.Set("alpha", nil)
+		om.Set("quote\"d", []int{2, 3})
+
+		b, err := json.Marshal(om)
+		require.NoError(t, err)
+
+		t.Log("Should encode keys in insertion order")
+		assert.Equal(t, `{"zeta":[1],"alpha":null,"quote\"d":[2,3]}`, string(b))
+
+		decoded := generic.NewOrderedMap[string, []int]()
+		require.NoError(t, json.Unmarshal(b, decoded))
+		assert.Equal(t, om.Keys(), decoded.Keys())
+		assert.Equal(t, om.Values(), decoded.Values())
+	})
+
+	t.Run("integer keys", func(t *testing.T) {
+		t.Parallel()
+
+		om := generic.NewOrderedMap[int, string]()
+		om.Set(10, "ten")
+		om.Set(2, "two")
+
+		b, err := json.Marshal(om)
+		require.NoError(t, err)
+		assert.Equal(t, `{"10":"ten","2":"two"}`, string(b))
+
+		decoded := generic.NewOrderedMap[int, string]()
+		require.NoError(t, json.Unmarshal(b, decoded))
+		assert.Equal(t, []int{10, 2}, decoded.Keys())
+	})
+
+	t.Run("empty and invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := json.Marshal(generic.NewOrderedMap[string, int]())
+		require.NoError(t, err)
+		assert.Equal(t, `{}`, string(b))
+
+		om := generic.NewOrderedMap[string, int]()
+		assert.Error(t, json.Unmarshal([]byte(`[1]`), om))
+		assert.Error(t, json.Unmarshal([]byte(`{"a":"x"}`), om))
+		assert.NoError(t, json.Unmarshal([]byte(`null`), om))
+	})
+
+	t.Run("nested in struct", func(t *testing.T) {
+		t.Parallel()
+
+		type doc struct {
+			Columns *generic.OrderedMap[string, string] `json:"columns"`
+		}
+		var d doc
+		require.NoError(t, json.Unmarshal([]byte(`{"columns":{"id":"int","name":"text"}}`), &d))
+		assert.Equal(t, []string{"id", "name"}, d.Columns.Keys())
+	})
+}
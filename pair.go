@@ -0,0 +1,112 @@
+package generic
+
+import "cmp"
+
+// Pair holds two values, typically a key and a value or elements
+// from two parallel slices
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// NewPair returns a Pair of a and b
+func NewPair[A any, B any](a A, b B) Pair[A, B] {
+	return Pair[A, B]{First: a, Second: b}
+}
+
+// Unpack returns both values of the pair
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// Zip pairs up the elements of a and b by index. If the slices differ
+// in length, the extra elements of the longer one are ignored.
+func Zip[A any, B any](a []A, b []B) []Pair[A, B] {
+	n := min(len(a), len(b))
+	pairs := make([]Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		pairs[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return pairs
+}
+
+// ZipLongest pairs up the elements of a and b by index. If the slices
+// differ in length, the shorter one is padded with fillA or fillB.
+func ZipLongest[A any, B any](a []A, b []B, fillA A, fillB B) []Pair[A, B] {
+	n := max(len(a), len(b))
+	pairs := make([]Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		p := Pair[A, B]{First: fillA, Second: fillB}
+		if i < len(a) {
+			p.First = a[i]
+		}
+		if i < len(b) {
+			p.Second = b[i]
+		}
+		pairs[i] = p
+	}
+	return pairs
+}
+
+// Unzip splits pairs into two parallel slices
+func Unzip[A any, B any](pairs []Pair[A, B]) ([]A, []B) {
+	a := make([]A, len(pairs))
+	b := make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i] = p.First
+		b[i] = p.Second
+	}
+	return a, b
+}
+
+// Enumerate pairs each element of a slice with its index
+func Enumerate[T any](s []T) []Pair[int, T] {
+	pairs := make([]Pair[int, T], len(s))
+	for i, e := range s {
+		pairs[i] = Pair[int, T]{First: i, Second: e}
+	}
+	return pairs
+}
+
+// ZipToMap builds a map from parallel slices of keys and values. Like
+// Zip, extra elements of the longer slice are ignored. If a key is
+// repeated, the last value wins.
+func ZipToMap[K comparable, V any](keys []K, values []V) map[K]V {
+	n := min(len(keys), len(values))
+	m := make(map[K]V, n)
+	for i := 0; i < n; i++ {
+		m[keys[i]] = values[i]
+	}
+	return m
+}
+
+// MapToPairs returns the map entries as a slice of pairs. Like Keys and
+// Values, the order is random. Use SortedMapToPairs for a stable order.
+func MapToPairs[K comparable, V any](m map[K]V) []Pair[K, V] {
+	pairs := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, Pair[K, V]{First: k, Second: v})
+	}
+	return pairs
+}
+
+// SortedMapToPairs returns the map entries as a slice of pairs ordered
+// by key, matching the order of SortedKeys and ValuesByKey
+func SortedMapToPairs[K cmp.Ordered, V any](m map[K]V) []Pair[K, V] {
+	keys := SortedKeys(m)
+	pairs := make([]Pair[K, V], len(keys))
+	for i, k := range keys {
+		pairs[i] = Pair[K, V]{First: k, Second: m[k]}
+	}
+	return pairs
+}
+
+// PairsToMap builds a map from a slice of pairs. If a key is repeated,
+// the last value wins.
+func PairsToMap[K comparable, V any](pairs []Pair[K, V]) map[K]V {
+	m := make(map[K]V, len(pairs))
+	for _, p := range pairs {
+		m[p.First] = p.Second
+	}
+	return m
+}
@@ -0,0 +1,102 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestPair(t *testing.T) {
+	t.Parallel()
+
+	p := generic.NewPair("id", 7)
+	a, b := p.Unpack()
+	assert.Equal(t, "id", a)
+	assert.Equal(t, 7, b)
+	assert.Equal(t, generic.Pair[string, int]{First: "id", Second: 7}, p)
+}
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal lengths", func(t *testing.T) {
+		t.Parallel()
+
+		pairs := generic.Zip([]string{"id", "name"}, []any{1, "bob"})
+		assert.Equal(t, []generic.Pair[string, any]{{"id", 1}, {"name", "bob"}}, pairs)
+	})
+
+	t.Run("truncates to shorter", func(t *testing.T) {
+		t.Parallel()
+
+		pairs := generic.Zip([]int{1, 2, 3}, []string{"a"})
+		assert.Equal(t, []generic.Pair[int, string]{{1, "a"}}, pairs)
+		assert.Empty(t, generic.Zip([]int{}, []string{"a"}))
+	})
+
+	t.Run("longest pads with fill values", func(t *testing.T) {
+		t.Parallel()
+
+		pairs := generic.ZipLongest([]int{1, 2, 3}, []string{"a"}, -1, "?")
+		assert.Equal(t, []generic.Pair[int, string]{{1, "a"}, {2, "?"}, {3, "?"}}, pairs)
+
+		pairs = generic.ZipLongest([]int{1}, []string{"a", "b"}, -1, "?")
+		assert.Equal(t, []generic.Pair[int, string]{{1, "a"}, {-1, "b"}}, pairs)
+	})
+}
+
+func TestUnzip(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"id", "name"}
+	values := []int{1, 2}
+	a, b := generic.Unzip(generic.Zip(names, values))
+
+	t.Log("Unzip should reverse Zip")
+	assert.Equal(t, names, a)
+	assert.Equal(t, values, b)
+
+	a, b = generic.Unzip([]generic.Pair[string, int]{})
+	assert.Empty(t, a)
+	assert.Empty(t, b)
+}
+
+func TestEnumerate(t *testing.T) {
+	t.Parallel()
+
+	pairs := generic.Enumerate([]string{"a", "b"})
+	assert.Equal(t, []generic.Pair[int, string]{{0, "a"}, {1, "b"}}, pairs)
+}
+
+func TestZipToMap(t *testing.T) {
+	t.Parallel()
+
+	m := generic.ZipToMap([]string{"id", "name", "id", "extra"}, []int{1, 2, 3})
+
+	t.Log("Should pair by index with the last duplicate winning")
+	assert.Equal(t, map[string]int{"id": 3, "name": 2}, m)
+}
+
+func TestMapPairs(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"c": 3, "a": 1, "b": 2}
+
+	t.Run("round trips", func(t *testing.T) {
+		t.Parallel()
+
+		pairs := generic.MapToPairs(m)
+		assert.Len(t, pairs, 3)
+		assert.Equal(t, m, generic.PairsToMap(pairs))
+	})
+
+	t.Run("sorted matches SortedKeys and ValuesByKey", func(t *testing.T) {
+		t.Parallel()
+
+		keys, values := generic.Unzip(generic.SortedMapToPairs(m))
+		assert.Equal(t, generic.SortedKeys(m), keys)
+		assert.Equal(t, generic.ValuesByKey(m), values)
+	})
+}
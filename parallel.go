@@ -0,0 +1,129 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrWorkerGoexit is returned by the parallel functions when a callback
+// calls runtime.Goexit, such as through t.FailNow, in a worker goroutine
+var ErrWorkerGoexit = errors.New("parallel worker exited with runtime.Goexit")
+
+// parallelFor calls f for each index in [0, n) using at most workers
+// goroutines. It stops early if ctx is cancelled, returning ctx.Err().
+// If f panics, the remaining work is abandoned and the panic is
+// re-raised in the calling goroutine once all workers have stopped. If f
+// calls runtime.Goexit, the remaining work is abandoned and
+// ErrWorkerGoexit is returned.
+func parallelFor(ctx context.Context, n int, workers int, f func(i int)) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	innerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var next, completed atomic.Int64
+	var panicOnce sync.Once
+	var panicked bool
+	var panicValue any
+	var goexited atomic.Bool
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			normalReturn := false
+			defer func() {
+				if normalReturn {
+					return
+				}
+				// recover returns nil after runtime.Goexit
+				if r := recover(); r != nil {
+					panicOnce.Do(func() {
+						panicked = true
+						panicValue = r
+					})
+				} else {
+					goexited.Store(true)
+				}
+				cancel()
+			}()
+			for innerCtx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					break
+				}
+				f(i)
+				completed.Add(1)
+			}
+			normalReturn = true
+		}()
+	}
+	wg.Wait()
+	if panicked {
+		panic(panicValue)
+	}
+	if int(completed.Load()) < n {
+		// Work stops early only when ctx is cancelled or a worker exits,
+		// so a partial result is never reported as success
+		if err := ctx.Err(); err != nil && !goexited.Load() {
+			return err
+		}
+		return ErrWorkerGoexit
+	}
+	return nil
+}
+
+// ParallelTransformSlice is TransformSlice with cast called from up to
+// workers goroutines at once. If workers is less than one, GOMAXPROCS is
+// used. The output order matches the input order.
+//
+// If ctx is cancelled before all elements are transformed, nil and
+// ctx.Err() are returned. If cast panics, the panic is re-raised in the
+// calling goroutine. If cast calls runtime.Goexit, nil and
+// ErrWorkerGoexit are returned.
+func ParallelTransformSlice[T any, U any](ctx context.Context, orig []T, workers int, cast func(T) U) ([]U, error) {
+	c := make([]U, len(orig))
+	err := parallelFor(ctx, len(orig), workers, func(i int) {
+		c[i] = cast(orig[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ParallelFilterSlice is FilterSlice with filter called from up to
+// workers goroutines at once. If workers is less than one, GOMAXPROCS is
+// used. The output order matches the input order and, like FilterSlice,
+// nil is returned when nothing matches.
+//
+// If ctx is cancelled before all elements are checked, nil and ctx.Err()
+// are returned. If filter panics, the panic is re-raised in the calling
+// goroutine. If filter calls runtime.Goexit, nil and ErrWorkerGoexit are
+// returned.
+func ParallelFilterSlice[T any](ctx context.Context, slice []T, workers int, filter func(T) bool) ([]T, error) {
+	keep := make([]bool, len(slice))
+	err := parallelFor(ctx, len(slice), workers, func(i int) {
+		keep[i] = filter(slice[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]T, 0, len(slice))
+	for i, item := range slice {
+		if keep[i] {
+			result = append(result, item)
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
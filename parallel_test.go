@@ -0,0 +1,173 @@
+package generic_test
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestParallelTransformSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("preserves order", func(t *testing.T) {
+		t.Parallel()
+
+		orig := make([]int, 1000)
+		for i := range orig {
+			orig[i] = i
+		}
+
+		result, err := generic.ParallelTransformSlice(context.Background(), orig, 8, strconv.Itoa)
+
+		t.Log("Should match TransformSlice")
+		require.NoError(t, err)
+		assert.Equal(t, generic.TransformSlice(orig, strconv.Itoa), result)
+	})
+
+	t.Run("caps concurrency", func(t *testing.T) {
+		t.Parallel()
+
+		var running, maxRunning atomic.Int32
+		orig := make([]int, 200)
+		_, err := generic.ParallelTransformSlice(context.Background(), orig, 3, func(i int) int {
+			n := running.Add(1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			running.Add(-1)
+			return i
+		})
+
+		t.Log("Should never run more than workers callbacks at once")
+		require.NoError(t, err)
+		assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+	})
+
+	t.Run("handles empty input", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.ParallelTransformSlice(context.Background(), []int{}, 0, strconv.Itoa)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("honors cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		orig := make([]int, 1000)
+		result, err := generic.ParallelTransformSlice(ctx, orig, 2, func(i int) int {
+			if calls.Add(1) == 10 {
+				cancel()
+			}
+			return i
+		})
+
+		t.Log("Should stop early and return the context error")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, result)
+		assert.Less(t, calls.Load(), int32(1000))
+	})
+
+	t.Run("propagates panics", func(t *testing.T) {
+		t.Parallel()
+
+		orig := []int{1, 2, 3, 4}
+
+		t.Log("A panic in a worker should be re-raised in the caller")
+		assert.PanicsWithValue(t, "boom", func() {
+			_, _ = generic.ParallelTransformSlice(context.Background(), orig, 2, func(i int) int {
+				if i == 3 {
+					panic("boom")
+				}
+				return i
+			})
+		})
+	})
+
+	t.Run("reports Goexit in a worker", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.ParallelTransformSlice(context.Background(), []int{1, 2, 3, 4}, 2, func(i int) int {
+			if i == 2 {
+				runtime.Goexit()
+			}
+			return i * 10
+		})
+
+		t.Log("Should return an error rather than a partial result")
+		assert.ErrorIs(t, err, generic.ErrWorkerGoexit)
+		assert.Nil(t, result)
+	})
+}
+
+func TestParallelFilterSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("preserves order", func(t *testing.T) {
+		t.Parallel()
+
+		orig := make([]int, 1000)
+		for i := range orig {
+			orig[i] = i
+		}
+		isEven := func(i int) bool { return i%2 == 0 }
+
+		result, err := generic.ParallelFilterSlice(context.Background(), orig, 4, isEven)
+
+		t.Log("Should match FilterSlice")
+		require.NoError(t, err)
+		assert.Equal(t, generic.FilterSlice(orig, isEven), result)
+	})
+
+	t.Run("returns nil for no matches", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.ParallelFilterSlice(context.Background(), []int{1, 3}, 4, func(i int) bool { return false })
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("honors cancelled context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result, err := generic.ParallelFilterSlice(ctx, []int{1, 2, 3}, 1, func(i int) bool { return true })
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, result)
+	})
+
+	t.Run("propagates panics", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			_, _ = generic.ParallelFilterSlice(context.Background(), []string{"a", "b"}, 0, func(s string) bool {
+				panic(s)
+			})
+		})
+	})
+	t.Run("reports Goexit in a worker", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.ParallelFilterSlice(context.Background(), []int{1, 2, 3}, 1, func(i int) bool {
+			if i == 3 {
+				runtime.Goexit()
+			}
+			return true
+		})
+		assert.ErrorIs(t, err, generic.ErrWorkerGoexit)
+		assert.Nil(t, result)
+	})
+}
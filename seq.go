@@ -0,0 +1,231 @@
+//go:build go1.23
+
+package generic
+
+import "iter"
+
+// This file provides lazy, iterator based counterparts for the slice and
+// map helpers. Nothing is allocated until a result is collected so
+// pipelines of these can process large inputs without intermediate slices.
+// Use slices.Values or maps.All to turn existing slices and maps into
+// sequences.
+
+// Collect gathers a sequence into a slice. For an empty sequence
+// nil is returned.
+func Collect[T any](seq iter.Seq[T]) []T {
+	var slice []T
+	for v := range seq {
+		slice = append(slice, v)
+	}
+	return slice
+}
+
+// CollectMap gathers a sequence of pairs into a map. Later pairs
+// override earlier pairs with the same key.
+func CollectMap[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	m := make(map[K]V)
+	for k, v := range seq {
+		m[k] = v
+	}
+	return m
+}
+
+// CollectSet gathers a sequence into a Set
+func CollectSet[T comparable](seq iter.Seq[T]) Set[T] {
+	s := make(Set[T])
+	for v := range seq {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// FilterSeq yields only the elements for which filter returns true.
+func FilterSeq[T any](seq iter.Seq[T], filter func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if filter(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq2 yields only the pairs for which filter returns true.
+func FilterSeq2[K any, V any](seq iter.Seq2[K, V], filter func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if filter(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+func TransformSeq[T any, U any](seq iter.Seq[T], cast func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(cast(v)) {
+				return
+			}
+		}
+	}
+}
+
+func CastStringySeq[B, A ~string | ~[]rune](seq iter.Seq[A]) iter.Seq[B] {
+	return func(yield func(B) bool) {
+		for a := range seq {
+			if !yield(B(a)) {
+				return
+			}
+		}
+	}
+}
+
+func SeqContains[T any](seq iter.Seq[T], filter func(t T) bool) bool {
+	for v := range seq {
+		if filter(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func SeqContainsElement[T comparable](seq iter.Seq[T], element T) bool {
+	return SeqContains(seq, func(t T) bool {
+		return t == element
+	})
+}
+
+func AllElementsSeq[T any](seq iter.Seq[T], filter func(t T) bool) bool {
+	for v := range seq {
+		if !filter(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func CountMatchingElementsSeq[T any](seq iter.Seq[T], filter func(T) bool) int {
+	var c int
+	for v := range seq {
+		if filter(v) {
+			c++
+		}
+	}
+	return c
+}
+
+// FirstMatchIndexSeq returns -1 if there are no matches
+func FirstMatchIndexSeq[T any](seq iter.Seq[T], filter func(T) bool) int {
+	var i int
+	for v := range seq {
+		if filter(v) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// CombineSeqs yields every element of each sequence in turn
+func CombineSeqs[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RemoveDuplicatesSeq yields each distinct element the first time it
+// is seen. Order is preserved. Memory used grows with the number of
+// distinct elements.
+func RemoveDuplicatesSeq[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for v := range seq {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// IntersectSeq yields the elements of b that are also in a, ordered
+// as per the order of b. All of a is consumed when iteration starts.
+func IntersectSeq[T comparable](a iter.Seq[T], b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		m := CollectSet(a)
+		for v := range b {
+			if _, ok := m[v]; ok && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// KeysSeq yields the map keys
+func KeysSeq[K comparable, V any](m map[K]V) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq yields the map values
+func ValuesSeq[K comparable, V any](m map[K]V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MissingKeysSeq yields the keys that are in a but not b
+func MissingKeysSeq[K comparable, V any](a, b map[K]V) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range a {
+			if _, ok := b[k]; !ok && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// CompareKeysSeq returns a sequence of keys that are only in a
+// and a sequence of keys that are only in b.
+func CompareKeysSeq[K comparable, V any](a, b map[K]V) (iter.Seq[K], iter.Seq[K]) {
+	return MissingKeysSeq(a, b), MissingKeysSeq(b, a)
+}
+
+// MergeSeq copies the pairs of seq onto a, overriding any common keys:
+// a is modified and returned. If a is nil, a new map is returned.
+func MergeSeq[K comparable, V any](a map[K]V, seq iter.Seq2[K, V]) map[K]V {
+	if a == nil {
+		return CollectMap(seq)
+	}
+	for k, v := range seq {
+		a[k] = v
+	}
+	return a
+}
+
+// All yields the keys and values of the map in insertion order.
+// It is safe to delete the current key during iteration.
+func (om *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		om.Range(yield)
+	}
+}
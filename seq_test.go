@@ -0,0 +1,198 @@
+//go:build go1.23
+
+package generic_test
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("collects slice", func(t *testing.T) {
+		t.Parallel()
+
+		t.Log("Should collect in sequence order")
+		assert.Equal(t, []int{1, 2, 3}, generic.Collect(slices.Values([]int{1, 2, 3})))
+		assert.Nil(t, generic.Collect(slices.Values([]int{})))
+	})
+
+	t.Run("collects map", func(t *testing.T) {
+		t.Parallel()
+
+		m := map[string]int{"a": 1, "b": 2}
+
+		t.Log("Should rebuild the map from its pairs")
+		assert.Equal(t, m, generic.CollectMap(maps.All(m)))
+	})
+
+	t.Run("collects set", func(t *testing.T) {
+		t.Parallel()
+
+		t.Log("Should collect unique members")
+		assert.Equal(t, generic.NewSet(1, 2), generic.CollectSet(slices.Values([]int{1, 2, 1})))
+	})
+}
+
+func TestFilterSeq(t *testing.T) {
+	t.Parallel()
+
+	t.Run("filters even numbers", func(t *testing.T) {
+		t.Parallel()
+
+		seq := generic.FilterSeq(slices.Values([]int{1, 2, 3, 4, 5, 6}), func(i int) bool { return i%2 == 0 })
+		assert.Equal(t, []int{2, 4, 6}, generic.Collect(seq))
+	})
+
+	t.Run("is lazy", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		seq := generic.FilterSeq(slices.Values([]int{1, 2, 3, 4}), func(i int) bool {
+			calls++
+			return true
+		})
+		assert.Equal(t, 0, calls)
+
+		for range seq {
+			break
+		}
+
+		t.Log("Should stop calling filter once the consumer stops")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("filters pairs", func(t *testing.T) {
+		t.Parallel()
+
+		m := map[string]int{"a": 1, "b": 2, "c": 3}
+		seq := generic.FilterSeq2(maps.All(m), func(k string, v int) bool { return k != "a" && v < 3 })
+		assert.Equal(t, map[string]int{"b": 2}, generic.CollectMap(seq))
+	})
+}
+
+func TestTransformSeq(t *testing.T) {
+	t.Parallel()
+
+	seq := generic.TransformSeq(slices.Values([]int{1, 2, 3}), strconv.Itoa)
+	assert.Equal(t, []string{"1", "2", "3"}, generic.Collect(seq))
+
+	runes := generic.CastStringySeq[[]rune](slices.Values([]string{"ab"}))
+	assert.Equal(t, [][]rune{[]rune("ab")}, generic.Collect(runes))
+}
+
+func TestSeqPredicates(t *testing.T) {
+	t.Parallel()
+
+	seq := slices.Values([]int{1, 2, 3, 4})
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	assert.True(t, generic.SeqContains(seq, isEven))
+	assert.False(t, generic.SeqContains(seq, func(i int) bool { return i > 4 }))
+	assert.True(t, generic.SeqContainsElement(seq, 3))
+	assert.False(t, generic.SeqContainsElement(seq, 5))
+	assert.False(t, generic.AllElementsSeq(seq, isEven))
+	assert.True(t, generic.AllElementsSeq(seq, func(i int) bool { return i > 0 }))
+	assert.Equal(t, 2, generic.CountMatchingElementsSeq(seq, isEven))
+	assert.Equal(t, 1, generic.FirstMatchIndexSeq(seq, isEven))
+	assert.Equal(t, -1, generic.FirstMatchIndexSeq(seq, func(i int) bool { return i > 4 }))
+}
+
+func TestCombineSeqs(t *testing.T) {
+	t.Parallel()
+
+	seq := generic.CombineSeqs(slices.Values([]int{1, 2}), slices.Values([]int{}), slices.Values([]int{3}))
+	assert.Equal(t, []int{1, 2, 3}, generic.Collect(seq))
+	assert.Nil(t, generic.Collect(generic.CombineSeqs[int]()))
+}
+
+func TestRemoveDuplicatesSeq(t *testing.T) {
+	t.Parallel()
+
+	seq := generic.RemoveDuplicatesSeq(slices.Values([]string{"a", "b", "b", "c", "a"}))
+
+	t.Log("Should remove duplicates while preserving order, and be reusable")
+	assert.Equal(t, []string{"a", "b", "c"}, generic.Collect(seq))
+	assert.Equal(t, []string{"a", "b", "c"}, generic.Collect(seq))
+}
+
+func TestIntersectSeq(t *testing.T) {
+	t.Parallel()
+
+	seq := generic.IntersectSeq(slices.Values([]int{5, 3, 1, 2, 4}), slices.Values([]int{4, 6, 2, 3}))
+
+	t.Log("Should preserve the order of b")
+	assert.Equal(t, []int{4, 2, 3}, generic.Collect(seq))
+}
+
+func TestMapSeqs(t *testing.T) {
+	t.Parallel()
+
+	a := map[string]int{"a": 1, "b": 2, "c": 3}
+	b := map[string]int{"b": 20, "d": 40}
+
+	assert.ElementsMatch(t, generic.Keys(a), generic.Collect(generic.KeysSeq(a)))
+	assert.ElementsMatch(t, generic.Values(a), generic.Collect(generic.ValuesSeq(a)))
+	assert.ElementsMatch(t, []string{"a", "c"}, generic.Collect(generic.MissingKeysSeq(a, b)))
+
+	onlyA, onlyB := generic.CompareKeysSeq(a, b)
+	assert.ElementsMatch(t, []string{"a", "c"}, generic.Collect(onlyA))
+	assert.Equal(t, []string{"d"}, generic.Collect(onlyB))
+}
+
+func TestMergeSeq(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merges pairs", func(t *testing.T) {
+		t.Parallel()
+
+		a := map[string]int{"a": 1, "b": 2}
+		result := generic.MergeSeq(a, maps.All(map[string]int{"b": 20, "c": 30}))
+
+		t.Log("Should modify and return a")
+		assert.Equal(t, map[string]int{"a": 1, "b": 20, "c": 30}, result)
+		assert.Equal(t, a, result)
+	})
+
+	t.Run("handles nil first map", func(t *testing.T) {
+		t.Parallel()
+
+		result := generic.MergeSeq(nil, maps.All(map[string]int{"x": 1}))
+		assert.Equal(t, map[string]int{"x": 1}, result)
+	})
+}
+
+func TestOrderedMapAll(t *testing.T) {
+	t.Parallel()
+
+	om := generic.NewOrderedMap[string, int]()
+	om.Set("b", 1)
+	om.Set("a", 2)
+
+	var keys []string
+	for k := range om.All() {
+		keys = append(keys, k)
+	}
+
+	t.Log("Should iterate in insertion order")
+	assert.Equal(t, []string{"b", "a"}, keys)
+
+	om.Set("c", 3)
+	keys = nil
+	for k := range om.All() {
+		keys = append(keys, k)
+		if k == "b" {
+			om.Delete("a")
+		}
+	}
+
+	t.Log("Should skip a key deleted during iteration")
+	assert.Equal(t, []string{"b", "c"}, keys)
+}
@@ -1,5 +1,18 @@
 package generic
 
+import (
+	"cmp"
+	"slices"
+)
+
+// Set is a set of comparable values. It is a plain map underneath so
+// it can be used anywhere a map[T]struct{} is expected and the result of
+// ToSet can be assigned directly to a Set.
+//
+// Like a map, a nil Set can be read but not written. Methods with an InPlace
+// suffix modify the receiver; the others always return a new Set.
+type Set[T comparable] map[T]struct{}
+
 func ToSet[T comparable](slice []T) map[T]struct{} {
 	m := make(map[T]struct{}, len(slice))
 	for _, item := range slice {
@@ -7,3 +20,213 @@ func ToSet[T comparable](slice []T) map[T]struct{} {
 	}
 	return m
 }
+
+// NewSet returns a Set containing items
+func NewSet[T comparable](items ...T) Set[T] {
+	return ToSet(items)
+}
+
+// SortedSlice returns the members of the set as a sorted slice
+func SortedSlice[T cmp.Ordered](s Set[T]) []T {
+	slice := s.Slice()
+	slices.Sort(slice)
+	return slice
+}
+
+// Add inserts items into the set
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+// Remove deletes items from the set. Items that are not
+// in the set are ignored.
+func (s Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+// Contains returns true if item is in the set
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// ContainsAll returns true if every item is in the set
+func (s Set[T]) ContainsAll(items ...T) bool {
+	for _, item := range items {
+		if _, ok := s[item]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns true if at least one item is in the set
+func (s Set[T]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if _, ok := s[item]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Copy returns a new Set with the same members. A nil Set copies to nil.
+func (s Set[T]) Copy() Set[T] {
+	return CopyMap(s)
+}
+
+// Slice returns the members of the set in no particular order
+func (s Set[T]) Slice() []T {
+	return Keys(s)
+}
+
+// SortedFunc returns the members of the set ordered by cmp
+// as per slices.SortFunc
+func (s Set[T]) SortedFunc(cmp func(a, b T) int) []T {
+	slice := s.Slice()
+	slices.SortFunc(slice, cmp)
+	return slice
+}
+
+// Equal returns true if both sets have exactly the same members
+func (s Set[T]) Equal(other Set[T]) bool {
+	return EqualKeys(s, other)
+}
+
+// IsSubset returns true if every member of s is in other
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for item := range s {
+		if _, ok := other[item]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true if every member of other is in s
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// IsDisjoint returns true if s and other have no members in common
+func (s Set[T]) IsDisjoint(other Set[T]) bool {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for item := range small {
+		if _, ok := large[item]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set with the members of both s and other
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	u := make(Set[T], len(s)+len(other))
+	for item := range s {
+		u[item] = struct{}{}
+	}
+	for item := range other {
+		u[item] = struct{}{}
+	}
+	return u
+}
+
+// UnionInPlace adds the members of other to s: s is modified and
+// returned. If s is nil, a copy of other is returned.
+func (s Set[T]) UnionInPlace(other Set[T]) Set[T] {
+	return Merge(s, other)
+}
+
+// Intersection returns a new set with the members that are in both s and other
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	i := make(Set[T], len(small))
+	for item := range small {
+		if _, ok := large[item]; ok {
+			i[item] = struct{}{}
+		}
+	}
+	return i
+}
+
+// IntersectionInPlace removes the members of s that are not in other:
+// s is modified and returned.
+func (s Set[T]) IntersectionInPlace(other Set[T]) Set[T] {
+	for item := range s {
+		if _, ok := other[item]; !ok {
+			delete(s, item)
+		}
+	}
+	return s
+}
+
+// Difference returns a new set with the members of s that are not in other
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	d := make(Set[T], len(s))
+	for item := range s {
+		if _, ok := other[item]; !ok {
+			d[item] = struct{}{}
+		}
+	}
+	return d
+}
+
+// DifferenceInPlace removes the members of other from s:
+// s is modified and returned.
+func (s Set[T]) DifferenceInPlace(other Set[T]) Set[T] {
+	for item := range other {
+		delete(s, item)
+	}
+	return s
+}
+
+// SymmetricDifference returns a new set with the members that are in
+// exactly one of s and other
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	d := make(Set[T])
+	for item := range s {
+		if _, ok := other[item]; !ok {
+			d[item] = struct{}{}
+		}
+	}
+	for item := range other {
+		if _, ok := s[item]; !ok {
+			d[item] = struct{}{}
+		}
+	}
+	return d
+}
+
+// SymmetricDifferenceInPlace modifies s to contain the members that
+// were in exactly one of s and other: s is modified and returned. If s
+// is nil, a copy of other is returned.
+func (s Set[T]) SymmetricDifferenceInPlace(other Set[T]) Set[T] {
+	if s == nil {
+		return other.Copy()
+	}
+	for item := range other {
+		if _, ok := s[item]; ok {
+			delete(s, item)
+		} else {
+			s[item] = struct{}{}
+		}
+	}
+	return s
+}
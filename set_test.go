@@ -56,3 +56,184 @@ func TestToSet(t *testing.T) {
 		assert.Empty(t, set)
 	})
 }
+
+func TestNewSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates set from items", func(t *testing.T) {
+		t.Parallel()
+
+		set := generic.NewSet("a", "b", "a")
+
+		t.Log("Should contain each unique item once")
+		assert.Equal(t, 2, set.Len())
+		assert.True(t, set.Contains("a"))
+		assert.True(t, set.Contains("b"))
+		assert.False(t, set.Contains("c"))
+	})
+
+	t.Run("accepts ToSet result", func(t *testing.T) {
+		t.Parallel()
+
+		var set generic.Set[int] = generic.ToSet([]int{1, 2})
+
+		t.Log("ToSet output should be assignable to Set")
+		assert.True(t, set.ContainsAll(1, 2))
+		assert.False(t, set.ContainsAll(1, 3))
+		assert.True(t, set.ContainsAny(3, 2))
+		assert.False(t, set.ContainsAny(3, 4))
+	})
+
+	t.Run("nil set can be read", func(t *testing.T) {
+		t.Parallel()
+
+		var set generic.Set[int]
+
+		t.Log("Nil set should behave as empty for reads")
+		assert.Equal(t, 0, set.Len())
+		assert.False(t, set.Contains(1))
+		assert.Nil(t, set.Copy())
+		assert.Empty(t, set.Slice())
+	})
+}
+
+func TestSetAddRemove(t *testing.T) {
+	t.Parallel()
+
+	set := generic.NewSet[int]()
+	set.Add(1, 2, 3)
+	set.Remove(2, 4)
+
+	t.Log("Should add and remove members, ignoring missing items")
+	assert.Equal(t, generic.NewSet(1, 3), set)
+}
+
+func TestSetSlices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsorted slice", func(t *testing.T) {
+		t.Parallel()
+
+		set := generic.NewSet(3, 1, 2)
+
+		t.Log("Should contain all members")
+		assert.ElementsMatch(t, []int{1, 2, 3}, set.Slice())
+	})
+
+	t.Run("sorted slice", func(t *testing.T) {
+		t.Parallel()
+
+		set := generic.NewSet("c", "a", "b")
+
+		t.Log("Should return members in sorted order")
+		assert.Equal(t, []string{"a", "b", "c"}, generic.SortedSlice(set))
+	})
+
+	t.Run("sorted with comparator", func(t *testing.T) {
+		t.Parallel()
+
+		set := generic.NewSet(3, 1, 2)
+
+		t.Log("Should return members in comparator order")
+		assert.Equal(t, []int{3, 2, 1}, set.SortedFunc(func(a, b int) int { return b - a }))
+	})
+}
+
+func TestSetRelations(t *testing.T) {
+	t.Parallel()
+
+	small := generic.NewSet(1, 2)
+	large := generic.NewSet(1, 2, 3)
+	other := generic.NewSet(4, 5)
+
+	t.Log("Should report subset, superset, disjoint and equality")
+	assert.True(t, small.IsSubset(large))
+	assert.False(t, large.IsSubset(small))
+	assert.True(t, large.IsSuperset(small))
+	assert.False(t, small.IsSuperset(large))
+	assert.True(t, small.IsDisjoint(other))
+	assert.False(t, small.IsDisjoint(large))
+	assert.True(t, small.Equal(generic.NewSet(2, 1)))
+	assert.False(t, small.Equal(large))
+
+	var empty generic.Set[int]
+	assert.True(t, empty.IsSubset(small))
+	assert.True(t, empty.IsDisjoint(small))
+}
+
+func TestSetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	a := generic.NewSet(1, 2, 3)
+	b := generic.NewSet(2, 3, 4)
+
+	t.Log("Copying operations should not modify their inputs")
+	assert.Equal(t, generic.NewSet(1, 2, 3, 4), a.Union(b))
+	assert.Equal(t, generic.NewSet(2, 3), a.Intersection(b))
+	assert.Equal(t, generic.NewSet(1), a.Difference(b))
+	assert.Equal(t, generic.NewSet(4), b.Difference(a))
+	assert.Equal(t, generic.NewSet(1, 4), a.SymmetricDifference(b))
+	assert.Equal(t, generic.NewSet(1, 2, 3), a)
+	assert.Equal(t, generic.NewSet(2, 3, 4), b)
+}
+
+func TestSetAlgebraInPlace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("union", func(t *testing.T) {
+		t.Parallel()
+
+		a := generic.NewSet(1, 2)
+		result := a.UnionInPlace(generic.NewSet(2, 3))
+
+		t.Log("Should modify and return the receiver")
+		assert.Equal(t, generic.NewSet(1, 2, 3), result)
+		assert.Equal(t, generic.NewSet(1, 2, 3), a)
+	})
+
+	t.Run("union into nil", func(t *testing.T) {
+		t.Parallel()
+
+		var a generic.Set[int]
+		b := generic.NewSet(1)
+		result := a.UnionInPlace(b)
+
+		t.Log("Should return a copy of other when receiver is nil")
+		assert.Equal(t, b, result)
+		result.Add(2)
+		assert.False(t, b.Contains(2))
+	})
+
+	t.Run("intersection", func(t *testing.T) {
+		t.Parallel()
+
+		a := generic.NewSet(1, 2, 3)
+		a.IntersectionInPlace(generic.NewSet(2, 3, 4))
+
+		t.Log("Should keep only common members")
+		assert.Equal(t, generic.NewSet(2, 3), a)
+	})
+
+	t.Run("difference", func(t *testing.T) {
+		t.Parallel()
+
+		a := generic.NewSet(1, 2, 3)
+		a.DifferenceInPlace(generic.NewSet(2, 3, 4))
+
+		t.Log("Should remove members of other")
+		assert.Equal(t, generic.NewSet(1), a)
+	})
+
+	t.Run("symmetric difference", func(t *testing.T) {
+		t.Parallel()
+
+		a := generic.NewSet(1, 2, 3)
+		a.SymmetricDifferenceInPlace(generic.NewSet(2, 3, 4))
+
+		t.Log("Should keep members in exactly one set")
+		assert.Equal(t, generic.NewSet(1, 4), a)
+
+		var n generic.Set[int]
+		assert.Equal(t, generic.NewSet(5), n.SymmetricDifferenceInPlace(generic.NewSet(5)))
+	})
+}
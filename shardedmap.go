@@ -0,0 +1,261 @@
+package generic
+
+import (
+	"hash/maphash"
+	"runtime"
+	"sync"
+)
+
+// ShardedMap is a map that is safe for concurrent use. Keys are spread
+// across independently locked shards by a hash function so that writers
+// to different shards do not contend. Use it instead of SyncMap for
+// maps with heavy concurrent writes.
+//
+// Operations on the whole map (Len, Keys, AllKeys, ...) lock every shard
+// so they see a consistent view. Filter functions passed to them must not
+// call methods on the map.
+type ShardedMap[K comparable, V any] struct {
+	hasher func(K) uint64
+	shards []mapShard[K, V]
+}
+
+type mapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	_  [32]byte // pad to a cache line to avoid false sharing between shards
+}
+
+// NewShardedMap returns an empty ShardedMap. hasher is required and
+// should spread keys evenly; see StringHasher and IntegerHasher. If
+// shardCount is less than one, a default based on GOMAXPROCS is used.
+func NewShardedMap[K comparable, V any](shardCount int, hasher func(K) uint64) *ShardedMap[K, V] {
+	if hasher == nil {
+		panic("generic: NewShardedMap requires a hasher")
+	}
+	if shardCount < 1 {
+		shardCount = 4 * runtime.GOMAXPROCS(0)
+	}
+	sm := &ShardedMap[K, V]{
+		hasher: hasher,
+		shards: make([]mapShard[K, V], shardCount),
+	}
+	for i := range sm.shards {
+		sm.shards[i].m = make(map[K]V)
+	}
+	return sm
+}
+
+// StringHasher returns a hasher for NewShardedMap for string keys
+func StringHasher[K ~string]() func(K) uint64 {
+	seed := maphash.MakeSeed()
+	return func(k K) uint64 {
+		return maphash.String(seed, string(k))
+	}
+}
+
+// IntegerHasher returns a hasher for NewShardedMap for integer keys
+func IntegerHasher[K ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr]() func(K) uint64 {
+	return func(k K) uint64 {
+		// splitmix64 finalizer so that sequential keys spread across shards
+		x := uint64(k)
+		x ^= x >> 30
+		x *= 0xbf58476d1ce4e5b9
+		x ^= x >> 27
+		x *= 0x94d049bb133111eb
+		x ^= x >> 31
+		return x
+	}
+}
+
+func (sm *ShardedMap[K, V]) shard(k K) *mapShard[K, V] {
+	return &sm.shards[sm.hasher(k)%uint64(len(sm.shards))]
+}
+
+func (sm *ShardedMap[K, V]) rlockAll() {
+	for i := range sm.shards {
+		sm.shards[i].mu.RLock()
+	}
+}
+
+func (sm *ShardedMap[K, V]) runlockAll() {
+	for i := range sm.shards {
+		sm.shards[i].mu.RUnlock()
+	}
+}
+
+// lenLocked must be called with all shards locked
+func (sm *ShardedMap[K, V]) lenLocked() int {
+	var n int
+	for i := range sm.shards {
+		n += len(sm.shards[i].m)
+	}
+	return n
+}
+
+// ShardCount returns the number of shards
+func (sm *ShardedMap[K, V]) ShardCount() int {
+	return len(sm.shards)
+}
+
+// Load returns the value stored under k and whether it was present
+func (sm *ShardedMap[K, V]) Load(k K) (V, bool) {
+	s := sm.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[k]
+	return v, ok
+}
+
+// Store sets the value for k
+func (sm *ShardedMap[K, V]) Store(k K, v V) {
+	s := sm.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[k] = v
+}
+
+// Delete removes k
+func (sm *ShardedMap[K, V]) Delete(k K) {
+	s := sm.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, k)
+}
+
+// LoadAndDelete removes k, returning its prior value and whether it
+// was present
+func (sm *ShardedMap[K, V]) LoadAndDelete(k K) (V, bool) {
+	s := sm.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[k]
+	delete(s.m, k)
+	return v, ok
+}
+
+// LoadOrStore returns the existing value for k if present. Otherwise
+// it stores and returns v. loaded is true if the value was already present.
+func (sm *ShardedMap[K, V]) LoadOrStore(k K, v V) (actual V, loaded bool) {
+	s := sm.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.m[k]; ok {
+		return existing, true
+	}
+	s.m[k] = v
+	return v, false
+}
+
+// Compute atomically replaces the value for k with the result of f.
+// It behaves like SyncMap.Compute. f must not call methods on sm.
+func (sm *ShardedMap[K, V]) Compute(k K, f func(v V, ok bool) (newV V, keep bool)) (V, bool) {
+	s := sm.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[k]
+	newV, keep := f(v, ok)
+	if !keep {
+		delete(s.m, k)
+		var zero V
+		return zero, false
+	}
+	s.m[k] = newV
+	return newV, true
+}
+
+func (sm *ShardedMap[K, V]) Len() int {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	return sm.lenLocked()
+}
+
+// Keys returns the map keys as a slice
+func (sm *ShardedMap[K, V]) Keys() []K {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	slice := make([]K, 0, sm.lenLocked())
+	for i := range sm.shards {
+		for k := range sm.shards[i].m {
+			slice = append(slice, k)
+		}
+	}
+	return slice
+}
+
+// Values returns the map values as a slice
+func (sm *ShardedMap[K, V]) Values() []V {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	slice := make([]V, 0, sm.lenLocked())
+	for i := range sm.shards {
+		for _, v := range sm.shards[i].m {
+			slice = append(slice, v)
+		}
+	}
+	return slice
+}
+
+// Snapshot returns a copy of the contents as a plain map
+func (sm *ShardedMap[K, V]) Snapshot() map[K]V {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	m := make(map[K]V, sm.lenLocked())
+	for i := range sm.shards {
+		for k, v := range sm.shards[i].m {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// AllKeys returns true if all keys satisfy filter.
+// Returns true for empty maps (vacuous truth).
+func (sm *ShardedMap[K, V]) AllKeys(filter func(K) bool) bool {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	for i := range sm.shards {
+		if !AllKeys(sm.shards[i].m, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyKey returns true if at least one key satisfies filter.
+// Returns false for empty maps.
+func (sm *ShardedMap[K, V]) AnyKey(filter func(K) bool) bool {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	for i := range sm.shards {
+		if AnyKey(sm.shards[i].m, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllValues returns true if all values satisfy filter.
+// Returns true for empty maps (vacuous truth).
+func (sm *ShardedMap[K, V]) AllValues(filter func(V) bool) bool {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	for i := range sm.shards {
+		if !AllValues(sm.shards[i].m, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyValue returns true if at least one value satisfies filter.
+// Returns false for empty maps.
+func (sm *ShardedMap[K, V]) AnyValue(filter func(V) bool) bool {
+	sm.rlockAll()
+	defer sm.runlockAll()
+	for i := range sm.shards {
+		if AnyValue(sm.shards[i].m, filter) {
+			return true
+		}
+	}
+	return false
+}
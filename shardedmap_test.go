@@ -0,0 +1,136 @@
+package generic_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestShardedMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("basic operations", func(t *testing.T) {
+		t.Parallel()
+
+		sm := generic.NewShardedMap[string, int](4, generic.StringHasher[string]())
+		assert.Equal(t, 4, sm.ShardCount())
+
+		sm.Store("a", 1)
+		v, ok := sm.Load("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+
+		actual, loaded := sm.LoadOrStore("a", 2)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, actual)
+		_, loaded = sm.LoadOrStore("b", 2)
+		assert.False(t, loaded)
+
+		v, ok = sm.Compute("b", func(v int, ok bool) (int, bool) { return v * 10, true })
+		assert.True(t, ok)
+		assert.Equal(t, 20, v)
+
+		v, ok = sm.LoadAndDelete("b")
+		assert.True(t, ok)
+		assert.Equal(t, 20, v)
+		sm.Delete("a")
+		assert.Equal(t, 0, sm.Len())
+	})
+
+	t.Run("whole map operations span shards", func(t *testing.T) {
+		t.Parallel()
+
+		sm := generic.NewShardedMap[int, string](8, generic.IntegerHasher[int]())
+		expected := make(map[int]string)
+		for i := 0; i < 100; i++ {
+			sm.Store(i, strconv.Itoa(i))
+			expected[i] = strconv.Itoa(i)
+		}
+
+		t.Log("Should match the plain map helpers on the same contents")
+		assert.Equal(t, 100, sm.Len())
+		assert.Equal(t, expected, sm.Snapshot())
+		assert.ElementsMatch(t, generic.Keys(expected), sm.Keys())
+		assert.ElementsMatch(t, generic.Values(expected), sm.Values())
+		assert.True(t, sm.AllKeys(func(k int) bool { return k < 100 }))
+		assert.False(t, sm.AllKeys(func(k int) bool { return k < 99 }))
+		assert.True(t, sm.AnyKey(func(k int) bool { return k == 99 }))
+		assert.False(t, sm.AnyKey(func(k int) bool { return k == 100 }))
+		assert.True(t, sm.AllValues(func(v string) bool { return v != "" }))
+		assert.False(t, sm.AllValues(func(v string) bool { return v != "5" }))
+		assert.True(t, sm.AnyValue(func(v string) bool { return v == "5" }))
+		assert.False(t, sm.AnyValue(func(v string) bool { return v == "x" }))
+	})
+
+	t.Run("empty map", func(t *testing.T) {
+		t.Parallel()
+
+		sm := generic.NewShardedMap[int, int](0, generic.IntegerHasher[int]())
+
+		t.Log("Should use a default shard count and follow the empty map conventions")
+		assert.Positive(t, sm.ShardCount())
+		assert.True(t, sm.AllKeys(func(int) bool { return false }))
+		assert.False(t, sm.AnyValue(func(int) bool { return true }))
+		assert.Empty(t, sm.Keys())
+	})
+
+	t.Run("requires hasher", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { generic.NewShardedMap[int, int](1, nil) })
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		sm := generic.NewShardedMap[int, int](16, generic.IntegerHasher[int]())
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					sm.Compute(i%50, func(v int, ok bool) (int, bool) { return v + 1, true })
+					_ = sm.AnyValue(func(v int) bool { return v < 0 })
+				}
+			}()
+		}
+		wg.Wait()
+
+		var total int
+		for _, v := range sm.Values() {
+			total += v
+		}
+		assert.Equal(t, 8000, total)
+		assert.Equal(t, 50, sm.Len())
+	})
+}
+
+func benchmarkMixed(b *testing.B, load func(int) (int, bool), store func(int, int)) {
+	b.RunParallel(func(pb *testing.PB) {
+		var i int
+		for pb.Next() {
+			k := i % 1024
+			if i%4 == 0 {
+				store(k, i)
+			} else {
+				_, _ = load(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedMap(b *testing.B) {
+	sm := generic.NewShardedMap[int, int](0, generic.IntegerHasher[int]())
+	benchmarkMixed(b, sm.Load, sm.Store)
+}
+
+func BenchmarkMutexMap(b *testing.B) {
+	var sm generic.SyncMap[int, int]
+	benchmarkMixed(b, sm.Load, sm.Store)
+}
@@ -1,5 +1,16 @@
 package generic
 
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrIndexOutOfRange is returned, wrapped, by the checked deletion
+// functions when given an index outside of the slice
+var ErrIndexOutOfRange = errors.New("index out of range")
+
 func CopySlice[T any](orig []T) []T {
 	c := make([]T, len(orig))
 	copy(c, orig)
@@ -80,6 +91,127 @@ func FirstMatchIndex[T any](s []T, filter func(T) bool) int {
 	return -1
 }
 
+// LastMatchIndex returns -1 if there are no matches
+func LastMatchIndex[T any](s []T, filter func(T) bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if filter(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// AllMatchIndexes returns the indexes of every match in ascending
+// order. Like FilterSlice, nil is returned if there are no matches.
+func AllMatchIndexes[T any](s []T, filter func(T) bool) []int {
+	var indexes []int
+	for i, e := range s {
+		if filter(e) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// IndexOfElement returns the index of the first element equal to element
+// or -1 if there is none
+func IndexOfElement[T comparable](s []T, element T) int {
+	return FirstMatchIndex(s, func(t T) bool {
+		return t == element
+	})
+}
+
+// FindFirst returns the first match. ok is false if there are no matches.
+func FindFirst[T any](s []T, filter func(T) bool) (T, bool) {
+	return elementAt(s, FirstMatchIndex(s, filter))
+}
+
+// FindLast returns the last match. ok is false if there are no matches.
+func FindLast[T any](s []T, filter func(T) bool) (T, bool) {
+	return elementAt(s, LastMatchIndex(s, filter))
+}
+
+// ArgMinBy returns the index of the smallest element as ordered by cmp,
+// or -1 if s is empty. If there are ties, the first index is returned.
+func ArgMinBy[T any](s []T, cmp func(a, b T) int) int {
+	if len(s) == 0 {
+		return -1
+	}
+	best := 0
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i], s[best]) < 0 {
+			best = i
+		}
+	}
+	return best
+}
+
+// ArgMaxBy returns the index of the largest element as ordered by cmp,
+// or -1 if s is empty. If there are ties, the first index is returned.
+func ArgMaxBy[T any](s []T, cmp func(a, b T) int) int {
+	if len(s) == 0 {
+		return -1
+	}
+	best := 0
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i], s[best]) > 0 {
+			best = i
+		}
+	}
+	return best
+}
+
+// ArgMin returns the index of the smallest element, or -1 if s is empty.
+// If there are ties, the first index is returned.
+func ArgMin[T cmp.Ordered](s []T) int {
+	return ArgMinBy(s, cmp.Compare[T])
+}
+
+// ArgMax returns the index of the largest element, or -1 if s is empty.
+// If there are ties, the first index is returned.
+func ArgMax[T cmp.Ordered](s []T) int {
+	return ArgMaxBy(s, cmp.Compare[T])
+}
+
+// MinBy returns the first smallest element as ordered by cmp. ok is
+// false if s is empty.
+func MinBy[T any](s []T, cmp func(a, b T) int) (T, bool) {
+	return elementAt(s, ArgMinBy(s, cmp))
+}
+
+// MaxBy returns the first largest element as ordered by cmp. ok is
+// false if s is empty.
+func MaxBy[T any](s []T, cmp func(a, b T) int) (T, bool) {
+	return elementAt(s, ArgMaxBy(s, cmp))
+}
+
+// MinMaxBy returns the first smallest and first largest elements as
+// ordered by cmp in a single pass. ok is false if s is empty.
+func MinMaxBy[T any](s []T, cmp func(a, b T) int) (minimum T, maximum T, ok bool) {
+	if len(s) == 0 {
+		return minimum, maximum, false
+	}
+	minimum, maximum = s[0], s[0]
+	for _, e := range s[1:] {
+		if cmp(e, minimum) < 0 {
+			minimum = e
+		}
+		if cmp(e, maximum) > 0 {
+			maximum = e
+		}
+	}
+	return minimum, maximum, true
+}
+
+// elementAt returns s[i] and true, or the zero value and false if i is -1
+func elementAt[T any](s []T, i int) (T, bool) {
+	if i == -1 {
+		var zero T
+		return zero, false
+	}
+	return s[i], true
+}
+
 // ReplaceFirstMatchOrAppend appends the new element unless there is a matching element
 func ReplaceOrAppend[T any](s []T, n T, filter func(T) bool) []T {
 	i := FirstMatchIndex(s, filter)
@@ -92,6 +224,63 @@ func ReplaceOrAppend[T any](s []T, n T, filter func(T) bool) []T {
 	return c
 }
 
+// ReplaceOrAppendInPlace is ReplaceOrAppend except that it modifies s
+// instead of copying it. Like append, the result must be used in place of s.
+func ReplaceOrAppendInPlace[T any](s []T, n T, filter func(T) bool) []T {
+	if i := FirstMatchIndex(s, filter); i != -1 {
+		s[i] = n
+		return s
+	}
+	return append(s, n)
+}
+
+// ReplaceAllMatches returns a copy of s with every matching element
+// replaced by n
+func ReplaceAllMatches[T any](s []T, n T, filter func(T) bool) []T {
+	c := CopySlice(s)
+	for i, e := range c {
+		if filter(e) {
+			c[i] = n
+		}
+	}
+	return c
+}
+
+// UpsertBy returns a copy of s with the first element that has the same
+// key as item replaced by item. If there is no such element, item is
+// appended.
+func UpsertBy[T any, K comparable](s []T, item T, key func(T) K) []T {
+	k := key(item)
+	return ReplaceOrAppend(s, item, func(e T) bool {
+		return key(e) == k
+	})
+}
+
+// UpsertMany is UpsertBy for a batch of items. It runs in O(n+m) time by
+// indexing s by key once rather than scanning s for each item. Items are
+// applied in order so if items has repeated keys the last one wins.
+func UpsertMany[T any, K comparable](s []T, items []T, key func(T) K) []T {
+	c := make([]T, len(s), len(s)+len(items))
+	copy(c, s)
+	index := make(map[K]int, len(s)+len(items))
+	for i, e := range c {
+		k := key(e)
+		if _, ok := index[k]; !ok {
+			index[k] = i
+		}
+	}
+	for _, item := range items {
+		k := key(item)
+		if i, ok := index[k]; ok {
+			c[i] = item
+		} else {
+			index[k] = len(c)
+			c = append(c, item)
+		}
+	}
+	return c
+}
+
 // CombineSlices may return the first slice if it is the only slice with elements.  A copy
 // is only made if it has to be made. For no input, nil is returned.
 func CombineSlices[T any](slices ...[]T) []T {
@@ -140,11 +329,201 @@ func RemoveDuplicates[T comparable](existing []T) []T {
 	return new
 }
 
+// RemoveDuplicatesBy removes members of a slice that have the same key
+// as an earlier member. Slice order is preserved. Use it for slices of
+// types that are not comparable by deriving a comparable key.
+func RemoveDuplicatesBy[T any, K comparable](existing []T, key func(T) K) []T {
+	if len(existing) <= 1 {
+		return existing
+	}
+	return removeDuplicatesBy(make([]T, 0, len(existing)), existing, key)
+}
+
+// RemoveDuplicatesFunc removes members of a slice that are equal to an
+// earlier member. Slice order is preserved. It runs in O(n^2) time so
+// prefer RemoveDuplicatesBy when a comparable key can be derived.
+func RemoveDuplicatesFunc[T any](existing []T, equal func(a, b T) bool) []T {
+	if len(existing) <= 1 {
+		return existing
+	}
+	return removeDuplicatesFunc(make([]T, 0, len(existing)), existing, equal)
+}
+
+// RemoveDuplicatesInPlace is RemoveDuplicates except that it reuses the
+// backing array of existing instead of allocating a new slice. The
+// original slice must not be used afterwards. Elements past the end of
+// the result are zeroed so they can be garbage collected.
+func RemoveDuplicatesInPlace[T comparable](existing []T) []T {
+	return RemoveDuplicatesByInPlace(existing, func(t T) T { return t })
+}
+
+// RemoveDuplicatesByInPlace is RemoveDuplicatesBy except that it reuses the
+// backing array of existing, as per RemoveDuplicatesInPlace.
+func RemoveDuplicatesByInPlace[T any, K comparable](existing []T, key func(T) K) []T {
+	if len(existing) <= 1 {
+		return existing
+	}
+	result := removeDuplicatesBy(existing[:0], existing, key)
+	clear(existing[len(result):])
+	return result
+}
+
+// RemoveDuplicatesFuncInPlace is RemoveDuplicatesFunc except that it reuses
+// the backing array of existing, as per RemoveDuplicatesInPlace.
+func RemoveDuplicatesFuncInPlace[T any](existing []T, equal func(a, b T) bool) []T {
+	if len(existing) <= 1 {
+		return existing
+	}
+	result := removeDuplicatesFunc(existing[:0], existing, equal)
+	clear(existing[len(result):])
+	return result
+}
+
+// removeDuplicatesBy appends the first member of src for each key to dst.
+// dst may share a backing array with src as long as it starts no later.
+func removeDuplicatesBy[T any, K comparable](dst []T, src []T, key func(T) K) []T {
+	seen := make(map[K]struct{}, len(src))
+	for _, value := range src {
+		k := key(value)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			dst = append(dst, value)
+		}
+	}
+	return dst
+}
+
+// removeDuplicatesFunc appends each member of src that is not equal to one
+// already in dst to dst. dst may share a backing array with src as long
+// as it starts no later.
+func removeDuplicatesFunc[T any](dst []T, src []T, equal func(a, b T) bool) []T {
+	for _, value := range src {
+		if !SliceContains(dst, func(t T) bool { return equal(t, value) }) {
+			dst = append(dst, value)
+		}
+	}
+	return dst
+}
+
 // DeleteFromSlice removes an item from a slice. It may reorder the original slice
-// in the process. It executes in O(1) time.
+// in the process. It executes in O(1) time. The vacated last element of the
+// original slice is zeroed so that it can be garbage collected. It panics if
+// index is out of range; see DeleteFromSliceChecked and DeleteStable.
 func DeleteFromSlice[T comparable](prior []T, index int) []T {
-	prior[index] = prior[len(prior)-1]
-	return prior[:len(prior)-1]
+	last := len(prior) - 1
+	prior[index] = prior[last]
+	var zero T
+	prior[last] = zero
+	return prior[:last]
+}
+
+// DeleteFromSliceChecked is DeleteFromSlice except that it returns an error
+// wrapping ErrIndexOutOfRange instead of panicking
+func DeleteFromSliceChecked[T comparable](prior []T, index int) ([]T, error) {
+	if err := checkIndex(prior, index); err != nil {
+		return prior, err
+	}
+	return DeleteFromSlice(prior, index), nil
+}
+
+// DeleteStable removes an item from a slice, preserving the order of the
+// remaining items. It modifies the original slice and executes in O(n)
+// time. Vacated elements at the end of the original slice are zeroed so
+// that they can be garbage collected. It panics if index is out of range.
+func DeleteStable[T any](prior []T, index int) []T {
+	return DeleteRange(prior, index, index+1)
+}
+
+// DeleteStableChecked is DeleteStable except that it returns an error
+// wrapping ErrIndexOutOfRange instead of panicking
+func DeleteStableChecked[T any](prior []T, index int) ([]T, error) {
+	if err := checkIndex(prior, index); err != nil {
+		return prior, err
+	}
+	return DeleteStable(prior, index), nil
+}
+
+// DeleteRange removes prior[from:to], preserving the order of the
+// remaining items. Like DeleteStable, it modifies the original slice and
+// zeroes the vacated elements. It panics if the range is invalid.
+func DeleteRange[T any](prior []T, from, to int) []T {
+	_ = prior[from:to] // bounds check
+	n := copy(prior[from:], prior[to:])
+	clear(prior[from+n:])
+	return prior[:from+n]
+}
+
+// DeleteRangeChecked is DeleteRange except that it returns an error
+// wrapping ErrIndexOutOfRange instead of panicking
+func DeleteRangeChecked[T any](prior []T, from, to int) ([]T, error) {
+	if from < 0 || to > len(prior) || from > to {
+		return prior, fmt.Errorf("%w: range [%d:%d] with length %d", ErrIndexOutOfRange, from, to, len(prior))
+	}
+	return DeleteRange(prior, from, to), nil
+}
+
+// DeleteIndexes removes the items at each of the indexes in a single
+// pass, preserving the order of the remaining items. The indexes may be in
+// any order and repeated indexes are ignored. Like DeleteStable, it
+// modifies the original slice and zeroes the vacated elements. It panics
+// if any index is out of range.
+func DeleteIndexes[T any](prior []T, indexes ...int) []T {
+	if len(indexes) == 0 {
+		return prior
+	}
+	sorted := slices.Clone(indexes)
+	slices.Sort(sorted)
+	if sorted[0] < 0 || sorted[len(sorted)-1] >= len(prior) {
+		panic(fmt.Sprintf("generic: DeleteIndexes index out of range with length %d", len(prior)))
+	}
+	kept := sorted[0]
+	next := 0
+	for i := sorted[0]; i < len(prior); i++ {
+		if next < len(sorted) && sorted[next] == i {
+			for next < len(sorted) && sorted[next] == i {
+				next++
+			}
+			continue
+		}
+		prior[kept] = prior[i]
+		kept++
+	}
+	clear(prior[kept:])
+	return prior[:kept]
+}
+
+// DeleteIndexesChecked is DeleteIndexes except that it returns an error
+// wrapping ErrIndexOutOfRange instead of panicking
+func DeleteIndexesChecked[T any](prior []T, indexes ...int) ([]T, error) {
+	for _, index := range indexes {
+		if err := checkIndex(prior, index); err != nil {
+			return prior, err
+		}
+	}
+	return DeleteIndexes(prior, indexes...), nil
+}
+
+// DeleteMatching removes the items for which filter returns true,
+// preserving the order of the remaining items. Unlike FilterSlice, it
+// modifies the original slice rather than allocating a new one. The
+// vacated elements are zeroed so that they can be garbage collected.
+func DeleteMatching[T any](prior []T, filter func(T) bool) []T {
+	kept := 0
+	for _, item := range prior {
+		if !filter(item) {
+			prior[kept] = item
+			kept++
+		}
+	}
+	clear(prior[kept:])
+	return prior[:kept]
+}
+
+func checkIndex[T any](s []T, index int) error {
+	if index < 0 || index >= len(s) {
+		return fmt.Errorf("%w: index %d with length %d", ErrIndexOutOfRange, index, len(s))
+	}
+	return nil
 }
 
 // IntersectSlices returns elements in common to two slices ordered as per the
@@ -159,3 +538,172 @@ func IntersectSlices[T comparable](a []T, b []T) []T {
 	}
 	return u
 }
+
+// GroupBy buckets the elements of a slice by the result of key. Within
+// each group the original order is preserved.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, e := range s {
+		k := key(e)
+		groups[k] = append(groups[k], e)
+	}
+	return groups
+}
+
+// Partition splits a slice into the elements for which filter returns
+// true and those for which it returns false, preserving order. Either
+// result is nil if it has no elements.
+func Partition[T any](s []T, filter func(T) bool) (yes []T, no []T) {
+	for _, e := range s {
+		if filter(e) {
+			yes = append(yes, e)
+		} else {
+			no = append(no, e)
+		}
+	}
+	return yes, no
+}
+
+// CountBy counts the elements of a slice by the result of key
+func CountBy[T any, K comparable](s []T, key func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, e := range s {
+		counts[key(e)]++
+	}
+	return counts
+}
+
+// Frequencies counts how many times each element appears in a slice
+func Frequencies[T comparable](s []T) map[T]int {
+	counts := make(map[T]int, len(s))
+	for _, e := range s {
+		counts[e]++
+	}
+	return counts
+}
+
+// IndexBy returns a map of the elements of a slice keyed by the result
+// of key. Keys must be unique: if two elements have the same key, nil
+// and an error wrapping ErrKeyCollision are returned. See SliceToMap
+// for a version that allows duplicates.
+func IndexBy[T any, K comparable](s []T, key func(T) K) (map[K]T, error) {
+	m := make(map[K]T, len(s))
+	for _, e := range s {
+		k := key(e)
+		if _, ok := m[k]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrKeyCollision, k)
+		}
+		m[k] = e
+	}
+	return m, nil
+}
+
+// Chunk splits a slice into consecutive chunks of n elements. The last
+// chunk may be shorter. Like CombineSlices, no copy is made: the chunks
+// share the backing array of s, but their capacity is limited so that
+// appending to one chunk cannot overwrite the next. Use ChunkCopy for
+// independent chunks. Chunk panics if n is less than one.
+func Chunk[T any](s []T, n int) [][]T {
+	if n < 1 {
+		panic("generic: Chunk size must be at least one")
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	// Written to avoid overflow when n is close to math.MaxInt
+	chunks := make([][]T, 0, (len(s)-1)/n+1)
+	for i := 0; i < len(s); i += n {
+		end := i + min(n, len(s)-i)
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
+// ChunkCopy is Chunk except that each chunk is a new slice
+func ChunkCopy[T any](s []T, n int) [][]T {
+	return copyChunks(Chunk(s, n))
+}
+
+// ChunkBy splits a slice into consecutive chunks whose total weight, as
+// measured by weight, does not exceed maxWeight. An element that is heavier
+// than maxWeight on its own is put in a chunk by itself. Like Chunk, the
+// chunks share the backing array of s; use ChunkByCopy for independent
+// chunks.
+func ChunkBy[T any](s []T, weight func(T) int, maxWeight int) [][]T {
+	if len(s) == 0 {
+		return nil
+	}
+	var chunks [][]T
+	var start, total int
+	for i, e := range s {
+		w := weight(e)
+		if i > start && total+w > maxWeight {
+			chunks = append(chunks, s[start:i:i])
+			start, total = i, 0
+		}
+		total += w
+	}
+	return append(chunks, s[start:len(s):len(s)])
+}
+
+// ChunkByCopy is ChunkBy except that each chunk is a new slice
+func ChunkByCopy[T any](s []T, weight func(T) int, maxWeight int) [][]T {
+	return copyChunks(ChunkBy(s, weight, maxWeight))
+}
+
+// SlidingWindow returns each run of size consecutive elements, starting
+// every step elements. Only complete windows are returned so if s is
+// shorter than size, nil is returned. The windows overlap when step is
+// less than size and they share the backing array of s; use
+// SlidingWindowCopy for independent windows. SlidingWindow panics if size
+// or step is less than one.
+func SlidingWindow[T any](s []T, size int, step int) [][]T {
+	if size < 1 || step < 1 {
+		panic("generic: SlidingWindow size and step must be at least one")
+	}
+	if len(s) < size {
+		return nil
+	}
+	windows := make([][]T, 0, (len(s)-size)/step+1)
+	for i := 0; ; i += step {
+		windows = append(windows, s[i:i+size:i+size])
+		// Compared this way so that a huge step cannot overflow i
+		if len(s)-size-i < step {
+			return windows
+		}
+	}
+}
+
+// SlidingWindowCopy is SlidingWindow except that each window is a new slice
+func SlidingWindowCopy[T any](s []T, size int, step int) [][]T {
+	return copyChunks(SlidingWindow(s, size, step))
+}
+
+func copyChunks[T any](chunks [][]T) [][]T {
+	for i, c := range chunks {
+		chunks[i] = CopySlice(c)
+	}
+	return chunks
+}
+
+// Batches calls f with consecutive chunks of up to n elements, as per
+// Chunk, stopping at the first error which is returned. The batches share
+// the backing array of s so f must not retain them.
+func Batches[T any](s []T, n int, f func(batch []T) error) error {
+	for _, batch := range Chunk(s, n) {
+		if err := f(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BatchesBy is Batches with the batches formed as per ChunkBy
+func BatchesBy[T any](s []T, weight func(T) int, maxWeight int, f func(batch []T) error) error {
+	for _, batch := range ChunkBy(s, weight, maxWeight) {
+		if err := f(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
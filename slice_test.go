@@ -3,10 +3,13 @@ package generic_test
 // This file generated with Claude 3.7 Sonnet
 
 import (
+	"errors"
+	"math"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/singlestore-labs/generic"
 )
@@ -960,3 +963,673 @@ func TestReplaceOrAppend(t *testing.T) {
 		}, result, "New person should be appended when no match found")
 	})
 }
+
+type groupRow struct {
+	Table string
+	Col   string
+}
+
+var groupRows = []groupRow{
+	{"users", "id"},
+	{"orders", "id"},
+	{"users", "name"},
+	{"orders", "total"},
+	{"users", "email"},
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("groups preserving order", func(t *testing.T) {
+		t.Parallel()
+
+		groups := generic.GroupBy(groupRows, func(r groupRow) string { return r.Table })
+
+		t.Log("Should bucket rows by key in their original order")
+		assert.Equal(t, map[string][]groupRow{
+			"users":  {{"users", "id"}, {"users", "name"}, {"users", "email"}},
+			"orders": {{"orders", "id"}, {"orders", "total"}},
+		}, groups)
+		assert.ElementsMatch(t, []string{"users", "orders"}, generic.Keys(groups))
+	})
+
+	t.Run("handles empty slice", func(t *testing.T) {
+		t.Parallel()
+
+		groups := generic.GroupBy([]int{}, func(i int) int { return i })
+		assert.Empty(t, groups)
+	})
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("splits by predicate", func(t *testing.T) {
+		t.Parallel()
+
+		yes, no := generic.Partition([]int{1, 2, 3, 4, 5}, func(i int) bool { return i%2 == 0 })
+		assert.Equal(t, []int{2, 4}, yes)
+		assert.Equal(t, []int{1, 3, 5}, no)
+	})
+
+	t.Run("returns nil for empty sides", func(t *testing.T) {
+		t.Parallel()
+
+		yes, no := generic.Partition([]int{2, 4}, func(i int) bool { return i%2 == 0 })
+		assert.Equal(t, []int{2, 4}, yes)
+		assert.Nil(t, no)
+	})
+}
+
+func TestCountBy(t *testing.T) {
+	t.Parallel()
+
+	counts := generic.CountBy(groupRows, func(r groupRow) string { return r.Table })
+	assert.Equal(t, map[string]int{"users": 3, "orders": 2}, counts)
+	assert.ElementsMatch(t, []int{3, 2}, generic.Values(counts))
+}
+
+func TestFrequencies(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, generic.Frequencies([]string{"a", "b", "a"}))
+	assert.Empty(t, generic.Frequencies([]string{}))
+}
+
+func TestIndexBy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("indexes unique keys", func(t *testing.T) {
+		t.Parallel()
+
+		index, err := generic.IndexBy(groupRows, func(r groupRow) string { return r.Table + "." + r.Col })
+		require.NoError(t, err)
+		assert.Len(t, index, 5)
+		assert.Equal(t, groupRow{"orders", "total"}, index["orders.total"])
+	})
+
+	t.Run("errors on duplicate keys", func(t *testing.T) {
+		t.Parallel()
+
+		index, err := generic.IndexBy(groupRows, func(r groupRow) string { return r.Col })
+
+		t.Log("Should fail when two elements share a key")
+		assert.Nil(t, index)
+		assert.ErrorIs(t, err, generic.ErrKeyCollision)
+		assert.Contains(t, err.Error(), ": id")
+	})
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("splits evenly and unevenly", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}}, generic.Chunk([]int{1, 2, 3, 4}, 2))
+		assert.Equal(t, [][]int{{1, 2, 3}, {4}}, generic.Chunk([]int{1, 2, 3, 4}, 3))
+		assert.Equal(t, [][]int{{1}}, generic.Chunk([]int{1}, 5))
+		assert.Nil(t, generic.Chunk([]int{}, 2))
+	})
+
+	t.Run("huge chunk size", func(t *testing.T) {
+		t.Parallel()
+
+		t.Log("A size near math.MaxInt should mean one chunk, not overflow")
+		assert.Equal(t, [][]int{{1, 2}}, generic.Chunk([]int{1, 2}, math.MaxInt))
+		assert.Equal(t, [][]int{{1, 2}}, generic.Chunk([]int{1, 2}, math.MaxInt-1))
+	})
+
+	t.Run("shares backing array safely", func(t *testing.T) {
+		t.Parallel()
+
+		s := []int{1, 2, 3, 4}
+		chunks := generic.Chunk(s, 2)
+
+		t.Log("Chunks alias the input but appending must not overwrite the next chunk")
+		s[0] = 99
+		assert.Equal(t, 99, chunks[0][0])
+		_ = append(chunks[0], 100)
+		assert.Equal(t, []int{3, 4}, chunks[1])
+	})
+
+	t.Run("copy variant", func(t *testing.T) {
+		t.Parallel()
+
+		s := []int{1, 2, 3}
+		chunks := generic.ChunkCopy(s, 2)
+		s[0] = 99
+		assert.Equal(t, [][]int{{1, 2}, {3}}, chunks)
+	})
+
+	t.Run("panics on invalid size", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { generic.Chunk([]int{1}, 0) })
+	})
+}
+
+func TestChunkBy(t *testing.T) {
+	t.Parallel()
+
+	length := func(s string) int { return len(s) }
+
+	t.Run("respects max weight", func(t *testing.T) {
+		t.Parallel()
+
+		chunks := generic.ChunkBy([]string{"aa", "bb", "c", "ddd", "e"}, length, 4)
+
+		t.Log("Should start a new chunk before exceeding the weight")
+		assert.Equal(t, [][]string{{"aa", "bb"}, {"c", "ddd"}, {"e"}}, chunks)
+	})
+
+	t.Run("oversized element gets its own chunk", func(t *testing.T) {
+		t.Parallel()
+
+		chunks := generic.ChunkBy([]string{"a", "toolong", "b"}, length, 3)
+		assert.Equal(t, [][]string{{"a"}, {"toolong"}, {"b"}}, chunks)
+	})
+
+	t.Run("copy variant", func(t *testing.T) {
+		t.Parallel()
+
+		s := []string{"a", "b", "c"}
+		chunks := generic.ChunkByCopy(s, length, 2)
+		s[0] = "z"
+		assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, chunks)
+		assert.Nil(t, generic.ChunkBy([]string{}, length, 2))
+	})
+}
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("overlapping windows", func(t *testing.T) {
+		t.Parallel()
+
+		windows := generic.SlidingWindow([]int{1, 2, 3, 4, 5}, 3, 1)
+		assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, windows)
+	})
+
+	t.Run("step larger than one", func(t *testing.T) {
+		t.Parallel()
+
+		windows := generic.SlidingWindow([]int{1, 2, 3, 4, 5, 6}, 2, 3)
+
+		t.Log("Should return only complete windows")
+		assert.Equal(t, [][]int{{1, 2}, {4, 5}}, windows)
+		assert.Nil(t, generic.SlidingWindow([]int{1}, 2, 1))
+	})
+
+	t.Run("huge step", func(t *testing.T) {
+		t.Parallel()
+
+		t.Log("A step near math.MaxInt should stop after the first window, not overflow")
+		assert.Equal(t, [][]int{{1}}, generic.SlidingWindow([]int{1, 2}, 1, math.MaxInt))
+		assert.Equal(t, [][]int{{1, 2}}, generic.SlidingWindow([]int{1, 2}, 2, math.MaxInt))
+		assert.Equal(t, [][]int{{1, 2}, {2, 3}}, generic.SlidingWindow([]int{1, 2, 3}, 2, 1))
+	})
+
+	t.Run("copy variant", func(t *testing.T) {
+		t.Parallel()
+
+		s := []int{1, 2, 3}
+		windows := generic.SlidingWindowCopy(s, 2, 1)
+		windows[0][1] = 99
+		assert.Equal(t, []int{1, 2, 3}, s)
+		assert.Equal(t, []int{2, 3}, windows[1])
+	})
+
+	t.Run("panics on invalid arguments", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { generic.SlidingWindow([]int{1}, 1, 0) })
+		assert.Panics(t, func() { generic.SlidingWindow([]int{1}, 0, 1) })
+	})
+}
+
+func TestBatches(t *testing.T) {
+	t.Parallel()
+
+	t.Run("calls f per batch", func(t *testing.T) {
+		t.Parallel()
+
+		var batches [][]int
+		err := generic.Batches([]int{1, 2, 3, 4, 5}, 2, func(batch []int) error {
+			batches = append(batches, generic.CopySlice(batch))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, batches)
+	})
+
+	t.Run("huge batch size means one batch", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		err := generic.Batches([]int{1, 2, 3}, math.MaxInt, func(batch []int) error {
+			calls++
+			assert.Equal(t, []int{1, 2, 3}, batch)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops at first error", func(t *testing.T) {
+		t.Parallel()
+
+		errStop := errors.New("stop")
+		var calls int
+		err := generic.Batches([]int{1, 2, 3, 4, 5}, 2, func(batch []int) error {
+			calls++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("batches by weight", func(t *testing.T) {
+		t.Parallel()
+
+		var sizes []int
+		err := generic.BatchesBy([]string{"aaa", "bb", "c", "dddd"}, func(s string) int { return len(s) }, 4, func(batch []string) error {
+			sizes = append(sizes, len(batch))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 1}, sizes)
+	})
+}
+
+func TestRemoveDuplicatesBy(t *testing.T) {
+	t.Parallel()
+
+	type column struct {
+		Name string
+		Tags []string
+	}
+	cols := []column{{"id", nil}, {"name", []string{"a"}}, {"id", []string{"b"}}, {"email", nil}}
+	byName := func(c column) string { return c.Name }
+
+	t.Run("keeps first of each key", func(t *testing.T) {
+		t.Parallel()
+
+		result := generic.RemoveDuplicatesBy(cols, byName)
+
+		t.Log("Should keep the first element for each key in order")
+		assert.Equal(t, []column{{"id", nil}, {"name", []string{"a"}}, {"email", nil}}, result)
+		assert.Len(t, cols, 4)
+	})
+
+	t.Run("equality function", func(t *testing.T) {
+		t.Parallel()
+
+		result := generic.RemoveDuplicatesFunc(cols, func(a, b column) bool { return a.Name == b.Name })
+		assert.Equal(t, generic.RemoveDuplicatesBy(cols, byName), result)
+	})
+
+	t.Run("short input", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, generic.RemoveDuplicatesBy([]column{}, byName))
+		assert.Len(t, generic.RemoveDuplicatesFunc(cols[:1], func(a, b column) bool { return true }), 1)
+	})
+}
+
+func TestRemoveDuplicatesInPlace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("comparable", func(t *testing.T) {
+		t.Parallel()
+
+		s := []int{1, 2, 2, 3, 1, 4}
+		result := generic.RemoveDuplicatesInPlace(s)
+
+		t.Log("Should reuse the backing array and zero the tail")
+		assert.Equal(t, []int{1, 2, 3, 4}, result)
+		assert.Same(t, &s[0], &result[0])
+		assert.Equal(t, []int{0, 0}, s[4:])
+	})
+
+	t.Run("by key", func(t *testing.T) {
+		t.Parallel()
+
+		a, b, c := "a", "b", "c"
+		s := []*string{&a, &b, &a, &c}
+		result := generic.RemoveDuplicatesByInPlace(s, func(p *string) string { return *p })
+		assert.Equal(t, []*string{&a, &b, &c}, result)
+		assert.Nil(t, s[3])
+	})
+
+	t.Run("equality function", func(t *testing.T) {
+		t.Parallel()
+
+		s := [][]int{{1}, {2}, {1}}
+		result := generic.RemoveDuplicatesFuncInPlace(s, func(a, b []int) bool { return a[0] == b[0] })
+		assert.Equal(t, [][]int{{1}, {2}}, result)
+		assert.Nil(t, s[2])
+	})
+}
+
+func TestDeleteFromSliceChecked(t *testing.T) {
+	t.Parallel()
+
+	s := []int{1, 2, 3}
+	result, err := generic.DeleteFromSliceChecked(s, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{2, 3}, result)
+
+	t.Log("The vacated element should be zeroed")
+	assert.Equal(t, 0, s[2])
+
+	_, err = generic.DeleteFromSliceChecked([]int{1}, 1)
+	assert.ErrorIs(t, err, generic.ErrIndexOutOfRange)
+	_, err = generic.DeleteFromSliceChecked([]int{}, 0)
+	assert.ErrorIs(t, err, generic.ErrIndexOutOfRange)
+}
+
+func TestDeleteStable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("preserves order", func(t *testing.T) {
+		t.Parallel()
+
+		s := []string{"a", "b", "c", "d"}
+		result := generic.DeleteStable(s, 1)
+
+		t.Log("Should remove the element and zero the tail")
+		assert.Equal(t, []string{"a", "c", "d"}, result)
+		assert.Equal(t, "", s[3])
+	})
+
+	t.Run("panics out of range", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { generic.DeleteStable([]int{1}, 1) })
+	})
+
+	t.Run("checked", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.DeleteStableChecked([]int{1, 2}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, result)
+
+		result, err = generic.DeleteStableChecked([]int{1, 2}, -1)
+		assert.ErrorIs(t, err, generic.ErrIndexOutOfRange)
+		assert.Equal(t, []int{1, 2}, result)
+	})
+}
+
+func TestDeleteRange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes range", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := 1, 2
+		s := []*int{&a, &b, &a, &b}
+		result := generic.DeleteRange(s, 1, 3)
+
+		assert.Equal(t, []*int{&a, &b}, result)
+		t.Log("Dropped pointers should be zeroed")
+		assert.Nil(t, s[2])
+		assert.Nil(t, s[3])
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []int{1, 2}, generic.DeleteRange([]int{1, 2}, 1, 1))
+		assert.Empty(t, generic.DeleteRange([]int{1, 2}, 0, 2))
+	})
+
+	t.Run("checked", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { generic.DeleteRange([]int{1, 2}, 1, 3) })
+
+		_, err := generic.DeleteRangeChecked([]int{1, 2}, 1, 3)
+		assert.ErrorIs(t, err, generic.ErrIndexOutOfRange)
+		_, err = generic.DeleteRangeChecked([]int{1, 2}, 2, 1)
+		assert.ErrorIs(t, err, generic.ErrIndexOutOfRange)
+
+		result, err := generic.DeleteRangeChecked([]int{1, 2, 3}, 0, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, result)
+	})
+}
+
+func TestDeleteIndexes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes many indexes", func(t *testing.T) {
+		t.Parallel()
+
+		s := []int{0, 1, 2, 3, 4, 5, 6}
+		result := generic.DeleteIndexes(s, 5, 1, 3, 1)
+
+		t.Log("Should accept unsorted and repeated indexes")
+		assert.Equal(t, []int{0, 2, 4, 6}, result)
+		assert.Equal(t, []int{0, 0, 0}, s[4:])
+	})
+
+	t.Run("no indexes", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []int{1, 2}, generic.DeleteIndexes([]int{1, 2}))
+	})
+
+	t.Run("checked", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { generic.DeleteIndexes([]int{1, 2}, 0, 2) })
+
+		s := []int{1, 2}
+		result, err := generic.DeleteIndexesChecked(s, 0, 2)
+		assert.ErrorIs(t, err, generic.ErrIndexOutOfRange)
+		t.Log("Slice should be untouched on error")
+		assert.Equal(t, []int{1, 2}, result)
+
+		result, err = generic.DeleteIndexesChecked(s, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, result)
+	})
+}
+
+func TestDeleteMatching(t *testing.T) {
+	t.Parallel()
+
+	s := []int{1, 2, 3, 4, 5, 6}
+	result := generic.DeleteMatching(s, func(i int) bool { return i%2 == 0 })
+
+	t.Log("Should remove matches in place, preserving order")
+	assert.Equal(t, []int{1, 3, 5}, result)
+	assert.Equal(t, []int{0, 0, 0}, s[3:])
+	assert.Empty(t, generic.DeleteMatching([]int{2}, func(i int) bool { return true }))
+}
+
+func TestReplaceOrAppendInPlace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("replaces in place", func(t *testing.T) {
+		t.Parallel()
+
+		s := []int{1, 2, 3}
+		result := generic.ReplaceOrAppendInPlace(s, 20, func(i int) bool { return i == 2 })
+
+		t.Log("Should modify the original slice")
+		assert.Equal(t, []int{1, 20, 3}, result)
+		assert.Equal(t, []int{1, 20, 3}, s)
+	})
+
+	t.Run("appends when no match", func(t *testing.T) {
+		t.Parallel()
+
+		result := generic.ReplaceOrAppendInPlace([]int{1}, 5, func(i int) bool { return i == 5 })
+		assert.Equal(t, []int{1, 5}, result)
+	})
+}
+
+func TestReplaceAllMatches(t *testing.T) {
+	t.Parallel()
+
+	s := []string{"a", "x", "b", "x"}
+	result := generic.ReplaceAllMatches(s, "y", func(e string) bool { return e == "x" })
+
+	t.Log("Should replace every match in a copy")
+	assert.Equal(t, []string{"a", "y", "b", "y"}, result)
+	assert.Equal(t, []string{"a", "x", "b", "x"}, s)
+}
+
+type upsertItem struct {
+	ID    int
+	Value string
+}
+
+func upsertKey(i upsertItem) int { return i.ID }
+
+func TestUpsertBy(t *testing.T) {
+	t.Parallel()
+
+	s := []upsertItem{{1, "a"}, {2, "b"}}
+
+	result := generic.UpsertBy(s, upsertItem{2, "B"}, upsertKey)
+	assert.Equal(t, []upsertItem{{1, "a"}, {2, "B"}}, result)
+	assert.Equal(t, "b", s[1].Value)
+
+	result = generic.UpsertBy(s, upsertItem{3, "c"}, upsertKey)
+	assert.Equal(t, []upsertItem{{1, "a"}, {2, "b"}, {3, "c"}}, result)
+}
+
+func TestUpsertMany(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merges batch", func(t *testing.T) {
+		t.Parallel()
+
+		s := []upsertItem{{1, "a"}, {2, "b"}, {3, "c"}}
+		items := []upsertItem{{2, "B"}, {4, "d"}, {4, "D"}, {1, "A"}}
+
+		result := generic.UpsertMany(s, items, upsertKey)
+
+		t.Log("Should replace existing keys in place and append new keys in order")
+		assert.Equal(t, []upsertItem{{1, "A"}, {2, "B"}, {3, "c"}, {4, "D"}}, result)
+		assert.Equal(t, "a", s[0].Value)
+	})
+
+	t.Run("matches repeated UpsertBy", func(t *testing.T) {
+		t.Parallel()
+
+		s := []upsertItem{{1, "a"}, {1, "dup"}}
+		items := []upsertItem{{1, "x"}, {5, "y"}}
+
+		expected := s
+		for _, item := range items {
+			expected = generic.UpsertBy(expected, item, upsertKey)
+		}
+		assert.Equal(t, expected, generic.UpsertMany(s, items, upsertKey))
+	})
+}
+
+func TestLastMatchIndex(t *testing.T) {
+	t.Parallel()
+
+	s := []int{2, 1, 4, 3}
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	assert.Equal(t, 2, generic.LastMatchIndex(s, isEven))
+	assert.Equal(t, -1, generic.LastMatchIndex(s, func(i int) bool { return i > 4 }))
+	assert.Equal(t, -1, generic.LastMatchIndex([]int{}, isEven))
+}
+
+func TestAllMatchIndexes(t *testing.T) {
+	t.Parallel()
+
+	s := []int{2, 1, 4, 3}
+
+	assert.Equal(t, []int{0, 2}, generic.AllMatchIndexes(s, func(i int) bool { return i%2 == 0 }))
+	t.Log("Should return nil for no matches")
+	assert.Nil(t, generic.AllMatchIndexes(s, func(i int) bool { return i > 4 }))
+}
+
+func TestIndexOfElement(t *testing.T) {
+	t.Parallel()
+
+	s := []string{"a", "b", "a"}
+	assert.Equal(t, 0, generic.IndexOfElement(s, "a"))
+	assert.Equal(t, 1, generic.IndexOfElement(s, "b"))
+	assert.Equal(t, -1, generic.IndexOfElement(s, "c"))
+}
+
+func TestFindFirstAndLast(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		Name string
+		N    int
+	}
+	s := []item{{"a", 1}, {"b", 2}, {"c", 1}}
+	isOne := func(i item) bool { return i.N == 1 }
+
+	first, ok := generic.FindFirst(s, isOne)
+	assert.True(t, ok)
+	assert.Equal(t, "a", first.Name)
+
+	last, ok := generic.FindLast(s, isOne)
+	assert.True(t, ok)
+	assert.Equal(t, "c", last.Name)
+
+	missing, ok := generic.FindFirst(s, func(i item) bool { return i.N > 2 })
+	assert.False(t, ok)
+	assert.Equal(t, item{}, missing)
+	_, ok = generic.FindLast([]item{}, isOne)
+	assert.False(t, ok)
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	byLen := func(a, b string) int { return len(a) - len(b) }
+	s := []string{"ccc", "a", "bb", "z", "yyy"}
+
+	t.Run("arg min and max", func(t *testing.T) {
+		t.Parallel()
+
+		t.Log("Ties should resolve to the first index")
+		assert.Equal(t, 1, generic.ArgMinBy(s, byLen))
+		assert.Equal(t, 0, generic.ArgMaxBy(s, byLen))
+		assert.Equal(t, 1, generic.ArgMin([]int{3, 1, 2, 1}))
+		assert.Equal(t, 0, generic.ArgMax([]int{3, 1, 3}))
+		assert.Equal(t, -1, generic.ArgMin([]int{}))
+		assert.Equal(t, -1, generic.ArgMaxBy([]string{}, byLen))
+	})
+
+	t.Run("min and max by", func(t *testing.T) {
+		t.Parallel()
+
+		minimum, ok := generic.MinBy(s, byLen)
+		assert.True(t, ok)
+		assert.Equal(t, "a", minimum)
+
+		maximum, ok := generic.MaxBy(s, byLen)
+		assert.True(t, ok)
+		assert.Equal(t, "ccc", maximum)
+
+		_, ok = generic.MinBy([]string{}, byLen)
+		assert.False(t, ok)
+	})
+
+	t.Run("min max in one pass", func(t *testing.T) {
+		t.Parallel()
+
+		minimum, maximum, ok := generic.MinMaxBy(s, byLen)
+		assert.True(t, ok)
+		assert.Equal(t, "a", minimum)
+		assert.Equal(t, "ccc", maximum)
+
+		_, _, ok = generic.MinMaxBy([]string{}, byLen)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,184 @@
+package generic
+
+import (
+	"cmp"
+	"slices"
+)
+
+// The functions in this file work on slices that are already sorted in
+// ascending order (by cmp.Compare, or by the supplied comparator for the
+// Func variants). They run in linear or logarithmic time without hashing.
+// Results are undefined if the input is not sorted.
+//
+// Where inputs contain duplicates they are treated as multisets: an
+// element that appears m times in a and n times in b appears min(m, n)
+// times in IntersectSorted, max(m, n) times in UnionSorted and m-n times
+// (if positive) in DifferenceSorted.
+
+// SearchSorted returns the position where x is or would be inserted
+// and whether x is present
+func SearchSorted[T cmp.Ordered](s []T, x T) (int, bool) {
+	return slices.BinarySearch(s, x)
+}
+
+// SearchSortedFunc is SearchSorted with a comparator
+func SearchSortedFunc[T any](s []T, x T, cmp func(a, b T) int) (int, bool) {
+	return slices.BinarySearchFunc(s, x, cmp)
+}
+
+// InsertSorted inserts x, keeping s sorted. Like append, it may modify
+// the backing array of s so the result must be used in place of s.
+func InsertSorted[T cmp.Ordered](s []T, x T) []T {
+	return InsertSortedFunc(s, x, cmp.Compare[T])
+}
+
+// InsertSortedFunc is InsertSorted with a comparator
+func InsertSortedFunc[T any](s []T, x T, cmp func(a, b T) int) []T {
+	i, _ := slices.BinarySearchFunc(s, x, cmp)
+	return slices.Insert(s, i, x)
+}
+
+// MergeSorted merges any number of sorted slices into a new sorted
+// slice. The merge is stable: equal elements keep the order of the
+// input slices. It runs in O(n log k) time for k slices.
+func MergeSorted[T cmp.Ordered](sorted ...[]T) []T {
+	return MergeSortedFunc(cmp.Compare[T], sorted...)
+}
+
+// MergeSortedFunc is MergeSorted with a comparator
+func MergeSortedFunc[T any](cmp func(a, b T) int, sorted ...[]T) []T {
+	switch len(sorted) {
+	case 0:
+		return nil
+	case 1:
+		return CopySlice(sorted[0])
+	}
+	// Merge adjacent pairs until one remains. Merging neighbours keeps
+	// the result stable.
+	for len(sorted) > 1 {
+		merged := make([][]T, 0, (len(sorted)+1)/2)
+		for i := 0; i < len(sorted); i += 2 {
+			if i+1 == len(sorted) {
+				merged = append(merged, sorted[i])
+			} else {
+				merged = append(merged, mergeTwo(sorted[i], sorted[i+1], cmp))
+			}
+		}
+		sorted = merged
+	}
+	return sorted[0]
+}
+
+func mergeTwo[T any](a, b []T, cmp func(a, b T) int) []T {
+	merged := make([]T, 0, len(a)+len(b))
+	var i, j int
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			merged = append(merged, b[j])
+			j++
+		} else {
+			merged = append(merged, a[i])
+			i++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	return append(merged, b[j:]...)
+}
+
+// UniqSorted returns a new slice with adjacent duplicates removed. It is
+// a faster RemoveDuplicates for sorted input. Like RemoveDuplicates,
+// input with fewer than two elements is returned as is.
+func UniqSorted[T cmp.Ordered](s []T) []T {
+	return UniqSortedFunc(s, cmp.Compare[T])
+}
+
+// UniqSortedFunc is UniqSorted with a comparator
+func UniqSortedFunc[T any](s []T, cmp func(a, b T) int) []T {
+	if len(s) <= 1 {
+		return s
+	}
+	uniq := make([]T, 1, len(s))
+	uniq[0] = s[0]
+	for _, e := range s[1:] {
+		if cmp(uniq[len(uniq)-1], e) != 0 {
+			uniq = append(uniq, e)
+		}
+	}
+	return uniq
+}
+
+// IntersectSorted returns the elements in common to two sorted slices
+func IntersectSorted[T cmp.Ordered](a, b []T) []T {
+	return IntersectSortedFunc(a, b, cmp.Compare[T])
+}
+
+// IntersectSortedFunc is IntersectSorted with a comparator
+func IntersectSortedFunc[T any](a, b []T, cmp func(a, b T) int) []T {
+	result := make([]T, 0, min(len(a), len(b)))
+	var i, j int
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// UnionSorted returns the sorted elements that are in either of two
+// sorted slices
+func UnionSorted[T cmp.Ordered](a, b []T) []T {
+	return UnionSortedFunc(a, b, cmp.Compare[T])
+}
+
+// UnionSortedFunc is UnionSorted with a comparator
+func UnionSortedFunc[T any](a, b []T, cmp func(a, b T) int) []T {
+	result := make([]T, 0, len(a)+len(b))
+	var i, j int
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			result = append(result, a[i])
+			i++
+		case c > 0:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
+
+// DifferenceSorted returns the sorted elements of a that are not in b
+func DifferenceSorted[T cmp.Ordered](a, b []T) []T {
+	return DifferenceSortedFunc(a, b, cmp.Compare[T])
+}
+
+// DifferenceSortedFunc is DifferenceSorted with a comparator
+func DifferenceSortedFunc[T any](a, b []T, cmp func(a, b T) int) []T {
+	result := make([]T, 0, len(a))
+	var i, j int
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			result = append(result, a[i])
+			i++
+		case c > 0:
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return append(result, a[i:]...)
+}
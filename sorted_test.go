@@ -0,0 +1,135 @@
+package generic_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+// byLength orders strings by length only so that stability is visible
+func byLength(a, b string) int {
+	return len(a) - len(b)
+}
+
+func TestSearchSorted(t *testing.T) {
+	t.Parallel()
+
+	s := []int{1, 3, 5, 7}
+
+	i, found := generic.SearchSorted(s, 5)
+	assert.True(t, found)
+	assert.Equal(t, 2, i)
+
+	i, found = generic.SearchSorted(s, 4)
+	assert.False(t, found)
+	assert.Equal(t, 2, i)
+
+	i, found = generic.SearchSortedFunc([]string{"a", "bb", "ccc"}, "xx", byLength)
+	assert.True(t, found)
+	assert.Equal(t, 1, i)
+}
+
+func TestInsertSorted(t *testing.T) {
+	t.Parallel()
+
+	var s []int
+	for _, x := range []int{5, 1, 3, 3, 9, 0} {
+		s = generic.InsertSorted(s, x)
+	}
+
+	t.Log("Should keep the slice sorted")
+	assert.Equal(t, []int{0, 1, 3, 3, 5, 9}, s)
+
+	words := generic.InsertSortedFunc([]string{"a", "ccc"}, "bb", byLength)
+	assert.Equal(t, []string{"a", "bb", "ccc"}, words)
+}
+
+func TestMergeSorted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("k-way merge", func(t *testing.T) {
+		t.Parallel()
+
+		merged := generic.MergeSorted([]int{1, 4, 7}, []int{2, 5, 8}, []int{}, []int{0, 3, 6, 9})
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, merged)
+	})
+
+	t.Run("stable for equal elements", func(t *testing.T) {
+		t.Parallel()
+
+		merged := generic.MergeSortedFunc(byLength, []string{"a", "bb"}, []string{"b", "cc"}, []string{"c"})
+
+		t.Log("Equal elements should keep the order of the input slices")
+		assert.Equal(t, []string{"a", "b", "c", "bb", "cc"}, merged)
+	})
+
+	t.Run("edge cases", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, generic.MergeSorted[int]())
+
+		s := []int{1, 2}
+		merged := generic.MergeSorted(s)
+		merged[0] = 99
+		t.Log("A single input should still be copied")
+		assert.Equal(t, []int{1, 2}, s)
+	})
+}
+
+func TestUniqSorted(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []int{1, 2, 3}, generic.UniqSorted([]int{1, 1, 2, 3, 3, 3}))
+	assert.Equal(t, []int{4}, generic.UniqSorted([]int{4}))
+	assert.Empty(t, generic.UniqSorted([]int{}))
+
+	t.Log("Should match RemoveDuplicates on sorted input")
+	s := []string{"a", "a", "b", "c", "c"}
+	assert.Equal(t, generic.RemoveDuplicates(s), generic.UniqSorted(s))
+
+	assert.Equal(t, []string{"a", "bb"}, generic.UniqSortedFunc([]string{"a", "b", "bb", "cc"}, byLength))
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	t.Parallel()
+
+	a := []int{1, 2, 2, 3, 5, 8}
+	b := []int{2, 3, 3, 4, 8, 9}
+
+	t.Run("intersect", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []int{2, 3, 8}, generic.IntersectSorted(a, b))
+		assert.Empty(t, generic.IntersectSorted(a, []int{}))
+		assert.Equal(t, generic.IntersectSlices([]int{1, 3, 5}, []int{3, 4, 5}), generic.IntersectSorted([]int{1, 3, 5}, []int{3, 4, 5}))
+	})
+
+	t.Run("union", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []int{1, 2, 2, 3, 3, 4, 5, 8, 9}, generic.UnionSorted(a, b))
+		assert.Equal(t, []int{1, 2}, generic.UnionSorted([]int{}, []int{1, 2}))
+	})
+
+	t.Run("difference", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []int{1, 2, 5}, generic.DifferenceSorted(a, b))
+		assert.Equal(t, []int{3, 4, 9}, generic.DifferenceSorted(b, a))
+	})
+
+	t.Run("comparator variants", func(t *testing.T) {
+		t.Parallel()
+
+		fold := func(x, y string) int { return strings.Compare(strings.ToLower(x), strings.ToLower(y)) }
+		x := []string{"A", "b", "C"}
+		y := []string{"a", "c", "D"}
+
+		assert.Equal(t, []string{"A", "C"}, generic.IntersectSortedFunc(x, y, fold))
+		assert.Equal(t, []string{"A", "b", "C", "D"}, generic.UnionSortedFunc(x, y, fold))
+		assert.Equal(t, []string{"b"}, generic.DifferenceSortedFunc(x, y, fold))
+	})
+}
@@ -0,0 +1,111 @@
+package generic
+
+import "slices"
+
+// Stack is a last-in first-out stack. The zero value is an empty stack
+// ready to use.
+type Stack[T any] struct {
+	items []T
+}
+
+// StackFromSlice returns a stack holding a copy of s with the last
+// element of s on top
+func StackFromSlice[T any](s []T) *Stack[T] {
+	return &Stack[T]{items: CopySlice(s)}
+}
+
+// Push adds v on top
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Pop removes and returns the top element. It returns false if the stack
+// is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	n := len(s.items) - 1
+	v := s.items[n]
+	s.items[n] = zero
+	s.items = s.items[:n]
+	return v, true
+}
+
+// Peek returns the top element without removing it. It returns false if
+// the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len returns the number of elements
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// IsEmpty returns true if the stack has no elements
+func (s *Stack[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Drain empties the stack, returning its elements in the order Pop would
+// have returned them
+func (s *Stack[T]) Drain() []T {
+	drained := CopySlice(s.items)
+	slices.Reverse(drained)
+	clear(s.items)
+	s.items = s.items[:0]
+	return drained
+}
+
+// Queue is a first-in first-out queue. The zero value is an empty queue
+// ready to use.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// QueueFromSlice returns a queue holding a copy of s with the first
+// element of s at the front
+func QueueFromSlice[T any](s []T) *Queue[T] {
+	return &Queue[T]{d: Deque[T]{buf: CopySlice(s), n: len(s)}}
+}
+
+// Push adds v at the back
+func (q *Queue[T]) Push(v T) {
+	q.d.PushBack(v)
+}
+
+// Pop removes and returns the front element. It returns false if the
+// queue is empty.
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.d.PopFront()
+}
+
+// Peek returns the front element without removing it. It returns false
+// if the queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.Front()
+}
+
+// Len returns the number of elements
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
+
+// IsEmpty returns true if the queue has no elements
+func (q *Queue[T]) IsEmpty() bool {
+	return q.d.Len() == 0
+}
+
+// Drain empties the queue, returning its elements in the order Pop would
+// have returned them
+func (q *Queue[T]) Drain() []T {
+	drained := q.d.ToSlice()
+	q.d.Clear()
+	return drained
+}
@@ -0,0 +1,102 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestStack(t *testing.T) {
+	t.Parallel()
+
+	t.Run("push and pop", func(t *testing.T) {
+		t.Parallel()
+
+		var s generic.Stack[int]
+		assert.True(t, s.IsEmpty())
+		_, ok := s.Pop()
+		assert.False(t, ok)
+		_, ok = s.Peek()
+		assert.False(t, ok)
+
+		s.Push(1)
+		s.Push(2)
+		assert.Equal(t, 2, s.Len())
+		v, ok := s.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, 2, v)
+
+		v, _ = s.Pop()
+		assert.Equal(t, 2, v)
+		v, _ = s.Pop()
+		assert.Equal(t, 1, v)
+		assert.True(t, s.IsEmpty())
+	})
+
+	t.Run("from slice and drain", func(t *testing.T) {
+		t.Parallel()
+
+		orig := []string{"bottom", "middle", "top"}
+		s := generic.StackFromSlice(orig)
+		s.Push("new")
+
+		t.Log("Should copy the slice")
+		assert.Equal(t, []string{"bottom", "middle", "top"}, orig)
+
+		assert.Equal(t, []string{"new", "top", "middle", "bottom"}, s.Drain())
+		assert.True(t, s.IsEmpty())
+		assert.Equal(t, []string{}, s.Drain())
+	})
+}
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("push and pop", func(t *testing.T) {
+		t.Parallel()
+
+		var q generic.Queue[int]
+		assert.True(t, q.IsEmpty())
+		_, ok := q.Pop()
+		assert.False(t, ok)
+		_, ok = q.Peek()
+		assert.False(t, ok)
+
+		for i := 1; i <= 20; i++ {
+			q.Push(i)
+		}
+		v, ok := q.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+		for i := 1; i <= 20; i++ {
+			v, ok := q.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		assert.True(t, q.IsEmpty())
+	})
+
+	t.Run("from slice and drain", func(t *testing.T) {
+		t.Parallel()
+
+		orig := []int{1, 2, 3}
+		q := generic.QueueFromSlice(orig)
+		q.Push(4)
+		v, _ := q.Pop()
+		assert.Equal(t, 1, v)
+		q.Push(5)
+
+		t.Log("Should copy the slice")
+		assert.Equal(t, []int{1, 2, 3}, orig)
+
+		assert.Equal(t, 4, q.Len())
+		assert.Equal(t, []int{2, 3, 4, 5}, q.Drain())
+		assert.True(t, q.IsEmpty())
+
+		empty := generic.QueueFromSlice([]int(nil))
+		empty.Push(7)
+		assert.Equal(t, []int{7}, empty.Drain())
+	})
+}
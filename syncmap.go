@@ -0,0 +1,294 @@
+package generic
+
+import "sync"
+
+// SyncMap is a map that is safe for concurrent use. Unlike sync.Map it
+// is typed. The zero value is an empty map ready to use. A SyncMap must
+// not be copied after first use.
+type SyncMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewSyncMap returns a SyncMap holding a copy of m
+func NewSyncMap[K comparable, V any](m map[K]V) *SyncMap[K, V] {
+	return &SyncMap[K, V]{m: CopyMap(m)}
+}
+
+// lazyInit must be called with the write lock held
+func (sm *SyncMap[K, V]) lazyInit() {
+	if sm.m == nil {
+		sm.m = make(map[K]V)
+	}
+}
+
+// Load returns the value stored under k and whether it was present
+func (sm *SyncMap[K, V]) Load(k K) (V, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	v, ok := sm.m[k]
+	return v, ok
+}
+
+// Store sets the value for k
+func (sm *SyncMap[K, V]) Store(k K, v V) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.lazyInit()
+	sm.m[k] = v
+}
+
+// Delete removes k
+func (sm *SyncMap[K, V]) Delete(k K) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	delete(sm.m, k)
+}
+
+// LoadAndDelete removes k, returning its prior value and whether it
+// was present
+func (sm *SyncMap[K, V]) LoadAndDelete(k K) (V, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	v, ok := sm.m[k]
+	delete(sm.m, k)
+	return v, ok
+}
+
+// LoadOrStore returns the existing value for k if present. Otherwise
+// it stores and returns v. loaded is true if the value was already present.
+func (sm *SyncMap[K, V]) LoadOrStore(k K, v V) (actual V, loaded bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if existing, ok := sm.m[k]; ok {
+		return existing, true
+	}
+	sm.lazyInit()
+	sm.m[k] = v
+	return v, false
+}
+
+// Swap stores v under k, returning the prior value and whether it
+// was present
+func (sm *SyncMap[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.lazyInit()
+	previous, loaded = sm.m[k]
+	sm.m[k] = v
+	return previous, loaded
+}
+
+// CompareAndSwapFunc stores newV under k if k is present and equal
+// reports that its current value matches old. It returns true if the
+// swap happened. See CompareAndSwap for comparable values.
+func (sm *SyncMap[K, V]) CompareAndSwapFunc(k K, old, newV V, equal func(V, V) bool) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if current, ok := sm.m[k]; ok && equal(current, old) {
+		sm.m[k] = newV
+		return true
+	}
+	return false
+}
+
+// CompareAndDeleteFunc removes k if k is present and equal reports that
+// its current value matches old. It returns true if k was removed.
+// See CompareAndDelete for comparable values.
+func (sm *SyncMap[K, V]) CompareAndDeleteFunc(k K, old V, equal func(V, V) bool) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if current, ok := sm.m[k]; ok && equal(current, old) {
+		delete(sm.m, k)
+		return true
+	}
+	return false
+}
+
+// CompareAndSwap stores newV under k if the current value for k is old
+func CompareAndSwap[K comparable, V comparable](sm *SyncMap[K, V], k K, old, newV V) bool {
+	return sm.CompareAndSwapFunc(k, old, newV, func(a, b V) bool {
+		return a == b
+	})
+}
+
+// CompareAndDelete removes k if the current value for k is old
+func CompareAndDelete[K comparable, V comparable](sm *SyncMap[K, V], k K, old V) bool {
+	return sm.CompareAndDeleteFunc(k, old, func(a, b V) bool {
+		return a == b
+	})
+}
+
+// Compute atomically replaces the value for k with the result of f.
+// f is given the current value and whether it was present; if f returns
+// keep=false then k is removed. Compute returns the resulting value and
+// whether k is now present. f must not call methods on sm.
+func (sm *SyncMap[K, V]) Compute(k K, f func(v V, ok bool) (newV V, keep bool)) (V, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	v, ok := sm.m[k]
+	newV, keep := f(v, ok)
+	if !keep {
+		delete(sm.m, k)
+		var zero V
+		return zero, false
+	}
+	sm.lazyInit()
+	sm.m[k] = newV
+	return newV, true
+}
+
+// Update atomically replaces the value for k with the result of f, but
+// only if k is present. It returns the new value and true if k was
+// present. f must not call methods on sm.
+func (sm *SyncMap[K, V]) Update(k K, f func(V) V) (V, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	v, ok := sm.m[k]
+	if !ok {
+		return v, false
+	}
+	v = f(v)
+	sm.m[k] = v
+	return v, true
+}
+
+func (sm *SyncMap[K, V]) Len() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return len(sm.m)
+}
+
+// Clear removes all keys
+func (sm *SyncMap[K, V]) Clear() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.m = nil
+}
+
+// Range calls f for each key and value in a snapshot of the map,
+// stopping if f returns false. f may call methods on sm.
+func (sm *SyncMap[K, V]) Range(f func(k K, v V) bool) {
+	for k, v := range sm.Snapshot() {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
+// Keys returns the map keys as a slice
+func (sm *SyncMap[K, V]) Keys() []K {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return Keys(sm.m)
+}
+
+// Values returns the map values as a slice
+func (sm *SyncMap[K, V]) Values() []V {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return Values(sm.m)
+}
+
+// Snapshot returns a copy of the contents as a plain map. A new map
+// is always returned.
+func (sm *SyncMap[K, V]) Snapshot() map[K]V {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if sm.m == nil {
+		return make(map[K]V)
+	}
+	return CopyMap(sm.m)
+}
+
+// SyncSet is a Set that is safe for concurrent use. The zero value is
+// an empty set ready to use. A SyncSet must not be copied after first use.
+type SyncSet[T comparable] struct {
+	mu sync.RWMutex
+	s  Set[T]
+}
+
+// NewSyncSet returns a SyncSet containing items
+func NewSyncSet[T comparable](items ...T) *SyncSet[T] {
+	return &SyncSet[T]{s: ToSet(items)}
+}
+
+// Add inserts items into the set
+func (ss *SyncSet[T]) Add(items ...T) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.s == nil {
+		ss.s = make(Set[T])
+	}
+	ss.s.Add(items...)
+}
+
+// TryAdd inserts item, returning true if it was not already present
+func (ss *SyncSet[T]) TryAdd(item T) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.s.Contains(item) {
+		return false
+	}
+	if ss.s == nil {
+		ss.s = make(Set[T])
+	}
+	ss.s[item] = struct{}{}
+	return true
+}
+
+// Remove deletes items from the set
+func (ss *SyncSet[T]) Remove(items ...T) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.Remove(items...)
+}
+
+// TryRemove deletes item, returning true if it was present
+func (ss *SyncSet[T]) TryRemove(item T) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if !ss.s.Contains(item) {
+		return false
+	}
+	delete(ss.s, item)
+	return true
+}
+
+// Contains returns true if item is in the set
+func (ss *SyncSet[T]) Contains(item T) bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Contains(item)
+}
+
+func (ss *SyncSet[T]) Len() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return len(ss.s)
+}
+
+// Clear removes all members
+func (ss *SyncSet[T]) Clear() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s = nil
+}
+
+// Slice returns the members of the set in no particular order
+func (ss *SyncSet[T]) Slice() []T {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Slice()
+}
+
+// Snapshot returns a copy of the members as a plain Set. A new Set
+// is always returned.
+func (ss *SyncSet[T]) Snapshot() Set[T] {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	if ss.s == nil {
+		return make(Set[T])
+	}
+	return ss.s.Copy()
+}
@@ -0,0 +1,224 @@
+package generic_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestSyncMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("basic operations", func(t *testing.T) {
+		t.Parallel()
+
+		var sm generic.SyncMap[string, int]
+		_, ok := sm.Load("a")
+		assert.False(t, ok)
+
+		sm.Store("a", 1)
+		v, ok := sm.Load("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+
+		actual, loaded := sm.LoadOrStore("a", 2)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, actual)
+		actual, loaded = sm.LoadOrStore("b", 2)
+		assert.False(t, loaded)
+		assert.Equal(t, 2, actual)
+
+		previous, loaded := sm.Swap("b", 3)
+		assert.True(t, loaded)
+		assert.Equal(t, 2, previous)
+
+		v, ok = sm.LoadAndDelete("b")
+		assert.True(t, ok)
+		assert.Equal(t, 3, v)
+		assert.Equal(t, 1, sm.Len())
+
+		sm.Delete("a")
+		assert.Equal(t, 0, sm.Len())
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		t.Parallel()
+
+		sm := generic.NewSyncMap(map[string]int{"a": 1})
+
+		assert.False(t, generic.CompareAndSwap(sm, "a", 2, 3))
+		assert.True(t, generic.CompareAndSwap(sm, "a", 1, 3))
+		assert.False(t, generic.CompareAndSwap(sm, "missing", 0, 3))
+		assert.False(t, generic.CompareAndDelete(sm, "a", 1))
+		assert.True(t, generic.CompareAndDelete(sm, "a", 3))
+		assert.Equal(t, 0, sm.Len())
+
+		sliceMap := generic.NewSyncMap(map[string][]int{"a": {1}})
+		equal := func(a, b []int) bool { return len(a) == len(b) }
+		assert.True(t, sliceMap.CompareAndSwapFunc("a", []int{9}, []int{1, 2}, equal))
+		assert.True(t, sliceMap.CompareAndDeleteFunc("a", []int{0, 0}, equal))
+	})
+
+	t.Run("compute and update", func(t *testing.T) {
+		t.Parallel()
+
+		var sm generic.SyncMap[string, int]
+
+		v, ok := sm.Update("a", func(v int) int { return v + 1 })
+		t.Log("Update should not add missing keys")
+		assert.False(t, ok)
+		assert.Equal(t, 0, v)
+		assert.Equal(t, 0, sm.Len())
+
+		v, ok = sm.Compute("a", func(v int, ok bool) (int, bool) {
+			assert.False(t, ok)
+			return 10, true
+		})
+		assert.True(t, ok)
+		assert.Equal(t, 10, v)
+
+		v, ok = sm.Update("a", func(v int) int { return v + 1 })
+		assert.True(t, ok)
+		assert.Equal(t, 11, v)
+
+		_, ok = sm.Compute("a", func(v int, ok bool) (int, bool) { return 0, false })
+		t.Log("Compute returning keep=false should delete the key")
+		assert.False(t, ok)
+		assert.Equal(t, 0, sm.Len())
+	})
+
+	t.Run("snapshot interoperates with map helpers", func(t *testing.T) {
+		t.Parallel()
+
+		original := map[string]int{"a": 1, "b": 2}
+		sm := generic.NewSyncMap(original)
+		original["c"] = 3
+
+		snap := sm.Snapshot()
+		t.Log("NewSyncMap and Snapshot should copy")
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, snap)
+		snap["d"] = 4
+		assert.Equal(t, 2, sm.Len())
+
+		assert.ElementsMatch(t, generic.Keys(snap), append(sm.Keys(), "d"))
+		assert.ElementsMatch(t, []int{1, 2}, sm.Values())
+		assert.Equal(t, generic.NewSet("a", "b"), generic.Set[string](generic.ToSet(sm.Keys())))
+
+		var zero generic.SyncMap[string, int]
+		assert.NotNil(t, zero.Snapshot())
+
+		sm.Clear()
+		assert.Equal(t, 0, sm.Len())
+	})
+
+	t.Run("range may modify the map", func(t *testing.T) {
+		t.Parallel()
+
+		sm := generic.NewSyncMap(map[int]int{1: 1, 2: 2, 3: 3})
+		var visited int
+		sm.Range(func(k, v int) bool {
+			visited++
+			sm.Delete(k)
+			return true
+		})
+
+		assert.Equal(t, 3, visited)
+		assert.Equal(t, 0, sm.Len())
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		var sm generic.SyncMap[int, int]
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					sm.Compute(i%10, func(v int, ok bool) (int, bool) { return v + 1, true })
+					sm.LoadOrStore(100+g, g)
+					_, _ = sm.Load(i % 10)
+					_ = sm.Snapshot()
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		t.Log("Every Compute call should have been applied exactly once")
+		total := 0
+		for k, v := range sm.Snapshot() {
+			if k < 100 {
+				total += v
+			}
+		}
+		assert.Equal(t, 8000, total)
+		assert.Equal(t, 18, sm.Len())
+	})
+}
+
+func TestSyncSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("basic operations", func(t *testing.T) {
+		t.Parallel()
+
+		var ss generic.SyncSet[string]
+		ss.Add("a", "b")
+		assert.True(t, ss.Contains("a"))
+		assert.False(t, ss.TryAdd("a"))
+		assert.True(t, ss.TryAdd("c"))
+		assert.True(t, ss.TryRemove("c"))
+		assert.False(t, ss.TryRemove("c"))
+		ss.Remove("b")
+		assert.Equal(t, 1, ss.Len())
+		assert.Equal(t, []string{"a"}, ss.Slice())
+
+		ss.Clear()
+		assert.Equal(t, 0, ss.Len())
+		assert.True(t, ss.TryAdd("z"))
+	})
+
+	t.Run("snapshot is a copy", func(t *testing.T) {
+		t.Parallel()
+
+		ss := generic.NewSyncSet(1, 2)
+		snap := ss.Snapshot()
+		snap.Add(3)
+
+		assert.Equal(t, 2, ss.Len())
+		assert.True(t, snap.IsSuperset(generic.NewSet(1, 2)))
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		var ss generic.SyncSet[int]
+		var wg sync.WaitGroup
+		added := make([]int, 8)
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					if ss.TryAdd(i) {
+						added[g]++
+					}
+					_ = ss.Contains(i)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		t.Log("Each item should have been added by exactly one goroutine")
+		var total int
+		for _, n := range added {
+			total += n
+		}
+		assert.Equal(t, 500, total)
+		assert.Equal(t, 500, ss.Len())
+	})
+}
@@ -0,0 +1,178 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IndexError wraps an error returned by a callback with the index of
+// the slice element that it was called for.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %s", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// TryTransformSlice is TransformSlice with a callback that can fail. It
+// stops at the first error, returning nil and the error wrapped in an
+// *IndexError.
+func TryTransformSlice[T any, U any](orig []T, cast func(T) (U, error)) ([]U, error) {
+	c := make([]U, len(orig))
+	for i, a := range orig {
+		u, err := cast(a)
+		if err != nil {
+			return nil, &IndexError{Index: i, Err: err}
+		}
+		c[i] = u
+	}
+	return c, nil
+}
+
+// TryTransformSliceAllErrors is TryTransformSlice except that it calls
+// cast for every element. If any calls fail, nil and errors.Join of every
+// failure (each wrapped in an *IndexError) are returned.
+func TryTransformSliceAllErrors[T any, U any](orig []T, cast func(T) (U, error)) ([]U, error) {
+	c := make([]U, len(orig))
+	var errs []error
+	for i, a := range orig {
+		u, err := cast(a)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+		c[i] = u
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	return c, nil
+}
+
+// TryFilterSlice is FilterSlice with a callback that can fail. It
+// stops at the first error, returning nil and the error wrapped in an
+// *IndexError.
+func TryFilterSlice[T any](slice []T, filter func(T) (bool, error)) ([]T, error) {
+	result := make([]T, 0, len(slice))
+	for i, item := range slice {
+		keep, err := filter(item)
+		if err != nil {
+			return nil, &IndexError{Index: i, Err: err}
+		}
+		if keep {
+			result = append(result, item)
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// TryFilterSliceAllErrors is TryFilterSlice except that it calls
+// filter for every element. If any calls fail, nil and errors.Join of
+// every failure (each wrapped in an *IndexError) are returned.
+func TryFilterSliceAllErrors[T any](slice []T, filter func(T) (bool, error)) ([]T, error) {
+	result := make([]T, 0, len(slice))
+	var errs []error
+	for i, item := range slice {
+		keep, err := filter(item)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+		if keep {
+			result = append(result, item)
+		}
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// TrySliceContains is SliceContains with a callback that can fail. It
+// stops at the first error, returning the error wrapped in an *IndexError.
+func TrySliceContains[T any](slice []T, filter func(t T) (bool, error)) (bool, error) {
+	i, err := TryFirstMatchIndex(slice, filter)
+	return i != -1, err
+}
+
+// TryAllElements is AllElements with a callback that can fail. It
+// stops at the first error, returning the error wrapped in an *IndexError.
+func TryAllElements[T any](slice []T, filter func(t T) (bool, error)) (bool, error) {
+	for i, item := range slice {
+		ok, err := filter(item)
+		if err != nil {
+			return false, &IndexError{Index: i, Err: err}
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// TryCountMatchingElements is CountMatchingElements with a callback that
+// can fail. It stops at the first error, returning 0 and the error wrapped
+// in an *IndexError.
+func TryCountMatchingElements[T any](s []T, filter func(T) (bool, error)) (int, error) {
+	var c int
+	for i, e := range s {
+		ok, err := filter(e)
+		if err != nil {
+			return 0, &IndexError{Index: i, Err: err}
+		}
+		if ok {
+			c++
+		}
+	}
+	return c, nil
+}
+
+// TryCountMatchingElementsAllErrors is TryCountMatchingElements except
+// that it calls filter for every element. If any calls fail, 0 and
+// errors.Join of every failure (each wrapped in an *IndexError) are returned.
+func TryCountMatchingElementsAllErrors[T any](s []T, filter func(T) (bool, error)) (int, error) {
+	var c int
+	var errs []error
+	for i, e := range s {
+		ok, err := filter(e)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+		if ok {
+			c++
+		}
+	}
+	if len(errs) != 0 {
+		return 0, errors.Join(errs...)
+	}
+	return c, nil
+}
+
+// TryFirstMatchIndex is FirstMatchIndex with a callback that can fail.
+// It returns -1 if there are no matches. It stops at the first error,
+// returning -1 and the error wrapped in an *IndexError.
+func TryFirstMatchIndex[T any](s []T, filter func(T) (bool, error)) (int, error) {
+	for i, e := range s {
+		ok, err := filter(e)
+		if err != nil {
+			return -1, &IndexError{Index: i, Err: err}
+		}
+		if ok {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
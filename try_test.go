@@ -0,0 +1,179 @@
+package generic_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/singlestore-labs/generic"
+)
+
+func TestTryTransformSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts all elements", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TryTransformSlice([]string{"1", "2", "3"}, strconv.Atoi)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, result)
+	})
+
+	t.Run("stops at first error", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		result, err := generic.TryTransformSlice([]string{"1", "x", "y"}, func(s string) (int, error) {
+			calls++
+			return strconv.Atoi(s)
+		})
+
+		t.Log("Should wrap the error with the failing index")
+		assert.Nil(t, result)
+		assert.Equal(t, 2, calls)
+		var indexErr *generic.IndexError
+		require.ErrorAs(t, err, &indexErr)
+		assert.Equal(t, 1, indexErr.Index)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		assert.Contains(t, err.Error(), "index 1: ")
+	})
+
+	t.Run("collects all errors", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TryTransformSliceAllErrors([]string{"x", "1", "y"}, strconv.Atoi)
+
+		t.Log("Should report every failure")
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "index 0: ")
+		assert.Contains(t, err.Error(), "index 2: ")
+
+		result, err = generic.TryTransformSliceAllErrors([]string{"1"}, strconv.Atoi)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, result)
+	})
+}
+
+func TestTryFilterSlice(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd")
+	evenOrError := func(i int) (bool, error) {
+		if i%2 != 0 {
+			return false, errOdd
+		}
+		return i%4 == 0, nil
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TryFilterSlice([]int{2, 4, 6, 8}, evenOrError)
+		require.NoError(t, err)
+		assert.Equal(t, []int{4, 8}, result)
+
+		result, err = generic.TryFilterSlice([]int{2, 6}, evenOrError)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("stops at first error", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TryFilterSlice([]int{4, 3, 5}, evenOrError)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, errOdd)
+		var indexErr *generic.IndexError
+		require.ErrorAs(t, err, &indexErr)
+		assert.Equal(t, 1, indexErr.Index)
+	})
+
+	t.Run("collects all errors", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := generic.TryFilterSliceAllErrors([]int{4, 3, 5}, evenOrError)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, errOdd)
+		assert.Contains(t, err.Error(), "index 1: odd")
+		assert.Contains(t, err.Error(), "index 2: odd")
+
+		result, err = generic.TryFilterSliceAllErrors([]int{4, 2}, evenOrError)
+		require.NoError(t, err)
+		assert.Equal(t, []int{4}, result)
+	})
+}
+
+func TestTryPredicates(t *testing.T) {
+	t.Parallel()
+
+	errBad := errors.New("bad")
+	check := func(s string) (bool, error) {
+		if s == "bad" {
+			return false, errBad
+		}
+		return s == "yes", nil
+	}
+
+	t.Run("contains", func(t *testing.T) {
+		t.Parallel()
+
+		found, err := generic.TrySliceContains([]string{"no", "yes", "bad"}, check)
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		found, err = generic.TrySliceContains([]string{"no", "bad", "yes"}, check)
+		assert.False(t, found)
+		assert.ErrorIs(t, err, errBad)
+	})
+
+	t.Run("all elements", func(t *testing.T) {
+		t.Parallel()
+
+		all, err := generic.TryAllElements([]string{"yes", "yes"}, check)
+		require.NoError(t, err)
+		assert.True(t, all)
+
+		all, err = generic.TryAllElements([]string{"yes", "no", "bad"}, check)
+		require.NoError(t, err)
+		assert.False(t, all)
+
+		_, err = generic.TryAllElements([]string{"yes", "bad"}, check)
+		assert.ErrorIs(t, err, errBad)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		t.Parallel()
+
+		n, err := generic.TryCountMatchingElements([]string{"yes", "no", "yes"}, check)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		n, err = generic.TryCountMatchingElements([]string{"yes", "bad"}, check)
+		assert.Equal(t, 0, n)
+		assert.ErrorIs(t, err, errBad)
+
+		n, err = generic.TryCountMatchingElementsAllErrors([]string{"bad", "yes", "bad"}, check)
+		assert.Equal(t, 0, n)
+		assert.Contains(t, err.Error(), "index 0: bad")
+		assert.Contains(t, err.Error(), "index 2: bad")
+	})
+
+	t.Run("first match index", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := generic.TryFirstMatchIndex([]string{"no", "yes"}, check)
+		require.NoError(t, err)
+		assert.Equal(t, 1, i)
+
+		i, err = generic.TryFirstMatchIndex([]string{"no"}, check)
+		require.NoError(t, err)
+		assert.Equal(t, -1, i)
+
+		i, err = generic.TryFirstMatchIndex([]string{"bad", "yes"}, check)
+		assert.Equal(t, -1, i)
+		assert.ErrorIs(t, err, errBad)
+	})
+}